@@ -10,6 +10,7 @@ import (
 	"github.com/disgoorg/disgo/httpserver"
 	"github.com/disgoorg/disgo/internal/tokenhelper"
 	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/cached"
 	"github.com/disgoorg/disgo/sharding"
 	"github.com/disgoorg/disgo/voice"
 )
@@ -29,6 +30,8 @@ type config struct {
 	RestClientConfigOpts []rest.ClientConfigOpt
 	Rest                 rest.Rest
 	RestConfigOpts       []rest.ConfigOpt
+	CachedRest           bool
+	CachedRestConfigOpts []cached.ConfigOpt
 
 	EventManager           EventManager
 	EventManagerConfigOpts []EventManagerConfigOpt
@@ -98,6 +101,14 @@ func WithRestConfigOpts(opts ...rest.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithCachedRest wraps the rest.Rest with cached.New to serve entities from the cache.Caches when possible.
+func WithCachedRest(opts ...cached.ConfigOpt) ConfigOpt {
+	return func(config *config) {
+		config.CachedRest = true
+		config.CachedRestConfigOpts = append(config.CachedRestConfigOpts, opts...)
+	}
+}
+
 // WithEventManager lets you inject your own EventManager.
 func WithEventManager(eventManager EventManager) ConfigOpt {
 	return func(config *config) {
@@ -367,5 +378,9 @@ func BuildClient(
 	}
 	client.Caches = cfg.Caches
 
+	if cfg.CachedRest {
+		client.Rest = cached.New(client.Rest, client.Caches, cfg.CachedRestConfigOpts...)
+	}
+
 	return client, nil
 }
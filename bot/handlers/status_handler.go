@@ -20,6 +20,13 @@ func gatewayHandlerHeartbeatAck(client *bot.Client, sequenceNumber int, shardID
 	})
 }
 
+func gatewayHandlerReconnectAttempt(client *bot.Client, sequenceNumber int, shardID int, event gateway.EventReconnectAttempt) {
+	client.EventManager.DispatchEvent(&events.ReconnectAttempt{
+		GenericEvent:          events.NewGenericEvent(client, sequenceNumber, shardID),
+		EventReconnectAttempt: event,
+	})
+}
+
 func gatewayHandlerReady(client *bot.Client, sequenceNumber int, shardID int, event gateway.EventReady) {
 	client.Caches.SetSelfUser(event.User)
 
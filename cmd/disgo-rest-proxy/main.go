@@ -0,0 +1,66 @@
+// Command disgo-rest-proxy runs a rest/proxy.Proxy which lets many services share the rate limits of their Discord tokens.
+//
+// Services point their rest client at the proxy, e.g. rest.WithURL("http://localhost:7979/api/v10"),
+// and disable their own rate limiter with rest.WithRateLimiter(rest.NewNoopRateLimiter()).
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/proxy"
+)
+
+func main() {
+	addr := flag.String("addr", ":7979", "address to listen on")
+	url := flag.String("url", proxy.DefaultURL, "upstream url to forward requests to")
+	globalRequestsPerSecond := flag.Int("global-rps", rest.GlobalRequestsPerSecond, "global requests per second of each bot token, 0 disables the global rate limit")
+	idleTimeout := flag.Duration("idle-timeout", proxy.DefaultIdleTimeout, "time after which the rate limiter of a token without requests is closed, 0 keeps them forever")
+	logLevel := flag.String("log-level", "info", "log level (debug, info, warn, error)")
+	flag.Parse()
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		slog.Error("invalid log level", slog.Any("err", err))
+		os.Exit(1)
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
+	p := proxy.New(
+		proxy.WithLogger(logger),
+		proxy.WithURL(*url),
+		proxy.WithIdleTimeout(*idleTimeout),
+		proxy.WithRateLimiterConfigOpts(rest.WithGlobalRequestsPerSecond(*globalRequestsPerSecond)),
+	)
+	server := &http.Server{
+		Addr:    *addr,
+		Handler: p,
+	}
+
+	go func() {
+		logger.Info("starting rest proxy", slog.String("addr", *addr), slog.String("url", *url))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("error while running rest proxy", slog.Any("err", err))
+			os.Exit(1)
+		}
+	}()
+
+	s := make(chan os.Signal, 1)
+	signal.Notify(s, syscall.SIGINT, syscall.SIGTERM)
+	<-s
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("error while shutting down rest proxy", slog.Any("err", err))
+	}
+	p.Close(ctx)
+}
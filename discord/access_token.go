@@ -57,6 +57,7 @@ type GrantType string
 const (
 	GrantTypeAuthorizationCode GrantType = "authorization_code"
 	GrantTypeRefreshToken      GrantType = "refresh_token"
+	GrantTypeClientCredentials GrantType = "client_credentials"
 )
 
 // String returns the GrantType as a string.
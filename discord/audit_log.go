@@ -1,6 +1,8 @@
 package discord
 
 import (
+	"time"
+
 	"github.com/disgoorg/json/v2"
 	"github.com/disgoorg/snowflake/v2"
 )
@@ -314,6 +316,87 @@ func (c *AuditLogChange) UnmarshalOldValue(v any) error {
 	return json.Unmarshal(c.OldValue, v)
 }
 
+// AuditLogChangeValues unmarshals the old & new value of the AuditLogChange into T.
+// Missing values are returned as the zero value of T.
+func AuditLogChangeValues[T any](c AuditLogChange) (oldValue T, newValue T, err error) {
+	if len(c.OldValue) > 0 {
+		if err = json.Unmarshal(c.OldValue, &oldValue); err != nil {
+			return
+		}
+	}
+	if len(c.NewValue) > 0 {
+		err = json.Unmarshal(c.NewValue, &newValue)
+	}
+	return
+}
+
+// auditLogChangeValues is like AuditLogChangeValues but returns zero values if the values can't be unmarshalled into T.
+func auditLogChangeValues[T any](c AuditLogChange) (T, T) {
+	oldValue, newValue, err := AuditLogChangeValues[T](c)
+	if err != nil {
+		var zero T
+		return zero, zero
+	}
+	return oldValue, newValue
+}
+
+// RoleAdded returns the roles added to a member by an AuditLogChangeKeyRoleAdd change.
+func (c *AuditLogChange) RoleAdded() []PartialRole {
+	if c.Key != AuditLogChangeKeyRoleAdd {
+		return nil
+	}
+	_, roles := auditLogChangeValues[[]PartialRole](*c)
+	return roles
+}
+
+// RoleRemoved returns the roles removed from a member by an AuditLogChangeKeyRoleRemove change.
+func (c *AuditLogChange) RoleRemoved() []PartialRole {
+	if c.Key != AuditLogChangeKeyRoleRemove {
+		return nil
+	}
+	_, roles := auditLogChangeValues[[]PartialRole](*c)
+	return roles
+}
+
+// Permissions returns the old & new Permissions of an AuditLogChangeKeyPermissions, AuditLogChangeKeyAllow or AuditLogChangeKeyDeny change.
+func (c *AuditLogChange) Permissions() (oldValue Permissions, newValue Permissions) {
+	switch c.Key {
+	case AuditLogChangeKeyPermissions, AuditLogChangeKeyAllow, AuditLogChangeKeyDeny:
+		return auditLogChangeValues[Permissions](*c)
+	}
+	return 0, 0
+}
+
+// StringValues returns the old & new value of a change with string values like AuditLogChangeKeyName.
+// It returns empty strings if the values are missing or not strings, use AuditLogChangeValues to get the error.
+func (c *AuditLogChange) StringValues() (oldValue string, newValue string) {
+	return auditLogChangeValues[string](*c)
+}
+
+// IntValues returns the old & new value of a change with int values like AuditLogChangeKeyPosition.
+// It returns 0 if the values are missing or not ints, use AuditLogChangeValues to get the error.
+func (c *AuditLogChange) IntValues() (oldValue int, newValue int) {
+	return auditLogChangeValues[int](*c)
+}
+
+// BoolValues returns the old & new value of a change with bool values like AuditLogChangeKeyNSFW.
+// It returns false if the values are missing or not bools, use AuditLogChangeValues to get the error.
+func (c *AuditLogChange) BoolValues() (oldValue bool, newValue bool) {
+	return auditLogChangeValues[bool](*c)
+}
+
+// IDValues returns the old & new value of a change with snowflake.ID values like AuditLogChangeKeyOwnerID.
+// It returns 0 if the values are missing or not snowflake.ID(s), use AuditLogChangeValues to get the error.
+func (c *AuditLogChange) IDValues() (oldValue snowflake.ID, newValue snowflake.ID) {
+	return auditLogChangeValues[snowflake.ID](*c)
+}
+
+// TimeValues returns the old & new value of a change with timestamp values like AuditLogChangeKeyCommunicationDisabledUntil.
+// It returns nil if the values are missing or not timestamps, use AuditLogChangeValues to get the error.
+func (c *AuditLogChange) TimeValues() (oldValue *time.Time, newValue *time.Time) {
+	return auditLogChangeValues[*time.Time](*c)
+}
+
 // OptionalAuditLogEntryInfo (https://discord.com/developers/docs/resources/audit-log#audit-log-entry-object-optional-audit-entry-info)
 type OptionalAuditLogEntryInfo struct {
 	DeleteMemberDays              *string                    `json:"delete_member_days"`
@@ -0,0 +1,147 @@
+package discord
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// AuditLogTargetType is the type of entity an AuditLogEntry targets, see AuditLogEntry.TargetType.
+type AuditLogTargetType int
+
+// All AuditLogTargetType(s)
+const (
+	// AuditLogTargetTypeUnknown is used for entries without a target or with an unknown AuditLogEvent
+	AuditLogTargetTypeUnknown AuditLogTargetType = iota
+	AuditLogTargetTypeGuild
+	AuditLogTargetTypeChannel
+	AuditLogTargetTypeUser
+	AuditLogTargetTypeRole
+	AuditLogTargetTypeWebhook
+	AuditLogTargetTypeEmoji
+	AuditLogTargetTypeIntegration
+	AuditLogTargetTypeStageInstance
+	AuditLogTargetTypeSticker
+	AuditLogTargetTypeGuildScheduledEvent
+	AuditLogTargetTypeThread
+	AuditLogTargetTypeApplicationCommand
+	AuditLogTargetTypeSoundboardSound
+	AuditLogTargetTypeAutoModerationRule
+	AuditLogTargetTypeOnboardingPrompt
+)
+
+// TargetType returns the type of entity TargetID refers to based on the ActionType.
+func (e AuditLogEntry) TargetType() AuditLogTargetType {
+	switch e.ActionType {
+	case AuditLogEventGuildUpdate, AuditLogOnboardingCreate, AuditLogOnboardingUpdate, AuditLogHomeSettingsCreate, AuditLogHomeSettingsUpdate:
+		return AuditLogTargetTypeGuild
+
+	case AuditLogEventChannelCreate, AuditLogEventChannelUpdate, AuditLogEventChannelDelete,
+		AuditLogEventChannelOverwriteCreate, AuditLogEventChannelOverwriteUpdate, AuditLogEventChannelOverwriteDelete,
+		AuditLogEventMessageBulkDelete, AuditLogVoiceChannelStatusUpdate, AuditLogVoiceChannelStatusDelete:
+		return AuditLogTargetTypeChannel
+
+	case AuditLogEventMemberKick, AuditLogEventMemberBanAdd, AuditLogEventMemberBanRemove, AuditLogEventMemberUpdate,
+		AuditLogEventMemberRoleUpdate, AuditLogEventBotAdd, AuditLogEventMessageDelete, AuditLogEventMessagePin, AuditLogEventMessageUnpin,
+		AuditLogAutoModerationBlockMessage, AuditLogAutoModerationFlagToChannel, AuditLogAutoModerationUserCommunicationDisabled,
+		AuditLogAutoModerationQuarantineUser:
+		return AuditLogTargetTypeUser
+
+	case AuditLogEventRoleCreate, AuditLogEventRoleUpdate, AuditLogEventRoleDelete:
+		return AuditLogTargetTypeRole
+
+	case AuditLogEventWebhookCreate, AuditLogEventWebhookUpdate, AuditLogEventWebhookDelete:
+		return AuditLogTargetTypeWebhook
+
+	case AuditLogEventEmojiCreate, AuditLogEventEmojiUpdate, AuditLogEventEmojiDelete:
+		return AuditLogTargetTypeEmoji
+
+	case AuditLogEventIntegrationCreate, AuditLogEventIntegrationUpdate, AuditLogEventIntegrationDelete:
+		return AuditLogTargetTypeIntegration
+
+	case AuditLogEventStageInstanceCreate, AuditLogEventStageInstanceUpdate, AuditLogEventStageInstanceDelete:
+		return AuditLogTargetTypeStageInstance
+
+	case AuditLogEventStickerCreate, AuditLogEventStickerUpdate, AuditLogEventStickerDelete:
+		return AuditLogTargetTypeSticker
+
+	case AuditLogGuildScheduledEventCreate, AuditLogGuildScheduledEventUpdate, AuditLogGuildScheduledEventDelete:
+		return AuditLogTargetTypeGuildScheduledEvent
+
+	case AuditLogThreadCreate, AuditLogThreadUpdate, AuditLogThreadDelete:
+		return AuditLogTargetTypeThread
+
+	case AuditLogApplicationCommandPermissionUpdate:
+		return AuditLogTargetTypeApplicationCommand
+
+	case AuditLogSoundboardSoundCreate, AuditLogSoundboardSoundUpdate, AuditLogSoundboardSoundDelete:
+		return AuditLogTargetTypeSoundboardSound
+
+	case AuditLogAutoModerationRuleCreate, AuditLogAutoModerationRuleUpdate, AuditLogAutoModerationRuleDelete:
+		return AuditLogTargetTypeAutoModerationRule
+
+	case AuditLogOnboardingPromptCreate, AuditLogOnboardingPromptUpdate, AuditLogOnboardingPromptDelete:
+		return AuditLogTargetTypeOnboardingPrompt
+
+	default:
+		return AuditLogTargetTypeUnknown
+	}
+}
+
+// AuditLogTarget is the entity an AuditLogEntry targets, see AuditLogEntry.Target.
+// Only the field matching Type is set and only if the entity is included in the AuditLog.
+type AuditLogTarget struct {
+	Type AuditLogTargetType
+	// ID is the ID of the target or 0 if the entry has no target.
+	ID snowflake.ID
+
+	User                *User
+	Webhook             Webhook
+	Integration         Integration
+	GuildScheduledEvent *GuildScheduledEvent
+	Thread              *GuildThread
+	ApplicationCommand  ApplicationCommand
+	AutoModerationRule  *AutoModerationRule
+}
+
+// Target resolves the target of the entry from the entities included in the given AuditLog.
+// Audit logs don't include guilds, channels, roles & other entities, for them only Type & ID are set.
+func (e AuditLogEntry) Target(auditLog AuditLog) AuditLogTarget {
+	target := AuditLogTarget{Type: e.TargetType()}
+	if e.TargetID == nil {
+		return target
+	}
+	target.ID = *e.TargetID
+
+	switch target.Type {
+	case AuditLogTargetTypeUser:
+		target.User = findAuditLogEntity(auditLog.Users, target.ID, func(user User) snowflake.ID { return user.ID })
+	case AuditLogTargetTypeWebhook:
+		if webhook := findAuditLogEntity(auditLog.Webhooks, target.ID, Webhook.ID); webhook != nil {
+			target.Webhook = *webhook
+		}
+	case AuditLogTargetTypeIntegration:
+		if integration := findAuditLogEntity(auditLog.Integrations, target.ID, Integration.ID); integration != nil {
+			target.Integration = *integration
+		}
+	case AuditLogTargetTypeGuildScheduledEvent:
+		target.GuildScheduledEvent = findAuditLogEntity(auditLog.GuildScheduledEvents, target.ID, func(event GuildScheduledEvent) snowflake.ID { return event.ID })
+	case AuditLogTargetTypeThread:
+		target.Thread = findAuditLogEntity(auditLog.Threads, target.ID, GuildThread.ID)
+	case AuditLogTargetTypeApplicationCommand:
+		// the target is the application if the permissions of all commands were updated
+		if command := findAuditLogEntity(auditLog.ApplicationCommands, target.ID, ApplicationCommand.ID); command != nil {
+			target.ApplicationCommand = *command
+		}
+	case AuditLogTargetTypeAutoModerationRule:
+		target.AutoModerationRule = findAuditLogEntity(auditLog.AutoModerationRules, target.ID, func(rule AutoModerationRule) snowflake.ID { return rule.ID })
+	}
+	return target
+}
+
+func findAuditLogEntity[T any](entities []T, id snowflake.ID, idFunc func(T) snowflake.ID) *T {
+	for i := range entities {
+		if idFunc(entities[i]) == id {
+			return &entities[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+func TestAuditLogChange_TypedValues(t *testing.T) {
+	var entry AuditLogEntry
+	data := `{"id":"10","action_type":25,"target_id":"1","changes":[
+		{"key":"$add","new_value":[{"id":"2","name":"role"}]},
+		{"key":"permissions","old_value":"8","new_value":"2048"}
+	]}`
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		t.Fatalf("failed to unmarshal entry: %v", err)
+	}
+
+	added := entry.Changes[0].RoleAdded()
+	if len(added) != 1 || added[0].ID != 2 || added[0].Name != "role" {
+		t.Errorf("unexpected added roles: %+v", added)
+	}
+	if removed := entry.Changes[0].RoleRemoved(); removed != nil {
+		t.Errorf("expected no removed roles, got %+v", removed)
+	}
+
+	oldPerms, newPerms := entry.Changes[1].Permissions()
+	if oldPerms != PermissionAdministrator || newPerms != PermissionSendMessages {
+		t.Errorf("unexpected permissions: %d -> %d", oldPerms, newPerms)
+	}
+
+	auditLog := AuditLog{Users: []User{{ID: 3}, {ID: 1, Username: "target"}}}
+	target := entry.Target(auditLog)
+	if target.Type != AuditLogTargetTypeUser || target.ID != 1 {
+		t.Fatalf("unexpected target: %+v", target)
+	}
+	if target.User == nil || target.User.Username != "target" {
+		t.Errorf("expected target user to be resolved, got %+v", target.User)
+	}
+
+	roleID := snowflake.ID(4)
+	entry = AuditLogEntry{ActionType: AuditLogEventRoleCreate, TargetID: &roleID}
+	if target = entry.Target(auditLog); target.Type != AuditLogTargetTypeRole || target.ID != 4 || target.User != nil {
+		t.Errorf("unexpected target: %+v", target)
+	}
+}
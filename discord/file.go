@@ -2,10 +2,12 @@ package discord
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/textproto"
+	"sync"
 
 	"github.com/disgoorg/json/v2"
 
@@ -46,18 +48,12 @@ func PayloadWithFiles(v any, files ...*File) (*MultipartBuffer, error) {
 	}
 
 	for i, file := range files {
-		var name string
-		if file.Flags.Has(FileFlagSpoiler) {
-			name = "SPOILER_" + file.Name
-		} else {
-			name = file.Name
-		}
-		part, err = writer.CreatePart(partHeader(fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, name), "application/octet-stream"))
+		part, err = writer.CreatePart(partHeader(fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, file.fileName()), "application/octet-stream"))
 		if err != nil {
 			return nil, err
 		}
 
-		if _, err = io.Copy(part, file.Reader); err != nil {
+		if err = file.copyTo(part); err != nil {
 			return nil, err
 		}
 	}
@@ -68,6 +64,255 @@ func PayloadWithFiles(v any, files ...*File) (*MultipartBuffer, error) {
 	}, nil
 }
 
+// NewMultipartStream returns the given payload as multipart body with all files in it.
+// Unlike PayloadWithFiles, the files are not copied into memory but streamed while the body is being sent.
+func NewMultipartStream(v any, files ...*File) (*MultipartStream, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	streamFiles := make([]*multipartStreamFile, len(files))
+	for i, file := range files {
+		if file.Reader == nil && file.Open == nil {
+			return nil, fmt.Errorf("file %s has neither a Reader nor an Open func", file.Name)
+		}
+		streamFiles[i] = &multipartStreamFile{File: file, offset: -1}
+	}
+
+	return &MultipartStream{
+		ContentType: "multipart/form-data; boundary=" + boundary,
+		boundary:    boundary,
+		value:       v,
+		payload:     payload,
+		files:       streamFiles,
+	}, nil
+}
+
+// MultipartStream is a multipart body which is written while it is being read instead of being buffered in memory.
+// It can be opened multiple times to resend the body. Files are re-read by seeking back if their Reader is an io.Seeker,
+// by calling File.Open or, as a last resort, from a copy of the data which has been read from the Reader so far.
+// That copy keeps the whole file in memory until the MultipartStream is garbage collected,
+// so large files should be passed as io.Seeker (like *os.File) or with NewFileFunc.
+type MultipartStream struct {
+	ContentType string
+
+	boundary string
+	value    any
+	payload  []byte
+	files    []*multipartStreamFile
+
+	// done is closed when the last opened body has been written completely or closed
+	done chan struct{}
+	mu   sync.Mutex
+}
+
+type multipartStreamFile struct {
+	*File
+	// offset is the position of a seekable Reader when it was opened the first time
+	offset int64
+	// replay holds all data read from a Reader which can neither seek nor be reopened, which is the whole file after the first attempt
+	replay *bytes.Buffer
+}
+
+// open returns a reader positioned at the start of the file & an optional io.Closer which has to be closed after reading.
+func (f *multipartStreamFile) open() (io.Reader, io.Closer, error) {
+	opened := f.offset != -1
+
+	if f.Open != nil {
+		if f.Reader != nil && !opened {
+			f.offset = 0
+			return f.Reader, nil, nil
+		}
+		f.offset = 0
+		rc, err := f.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		return rc, rc, nil
+	}
+
+	if seeker, ok := f.Reader.(io.Seeker); ok {
+		var err error
+		if opened {
+			_, err = seeker.Seek(f.offset, io.SeekStart)
+		} else {
+			f.offset, err = seeker.Seek(0, io.SeekCurrent)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return f.Reader, nil, nil
+	}
+
+	f.offset = 0
+	if f.replay == nil {
+		f.replay = &bytes.Buffer{}
+	}
+	// replay what we read so far, then continue reading from the Reader while remembering the data for the next attempt
+	return io.MultiReader(bytes.NewReader(f.replay.Bytes()), io.TeeReader(f.Reader, f.replay)), nil, nil
+}
+
+// size returns the size of the file if it is known up front or -1 otherwise.
+func (f *multipartStreamFile) size() int64 {
+	opened := f.offset != -1
+	if f.Open != nil && (f.Reader == nil || opened) {
+		return -1
+	}
+
+	switch r := f.Reader.(type) {
+	case io.Seeker:
+		current, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := r.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err = r.Seek(current, io.SeekStart); err != nil {
+			return -1
+		}
+		if opened {
+			current = f.offset
+		}
+		return end - current
+
+	case interface{ Len() int }:
+		if !opened {
+			return int64(r.Len())
+		}
+	}
+	return -1
+}
+
+// Value returns the payload the MultipartStream was created with.
+func (m *MultipartStream) Value() any {
+	return m.value
+}
+
+// Len returns the length of the whole body in bytes or -1 if the size of a file is not known up front.
+// It waits until a previously opened body has been closed.
+func (m *MultipartStream) Len() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.done != nil {
+		<-m.done
+	}
+
+	var length int64
+	for _, file := range m.files {
+		size := file.size()
+		if size == -1 {
+			return -1
+		}
+		length += size
+	}
+
+	// write the body without file contents to count the multipart overhead
+	counter := &countingWriter{}
+	empty := make([]io.Reader, len(m.files))
+	for i := range empty {
+		empty[i] = bytes.NewReader(nil)
+	}
+	if err := m.write(counter, empty); err != nil {
+		return -1
+	}
+	return length + counter.n
+}
+
+// Open returns a new reader of the whole body. Each call starts from the beginning of the body.
+// The returned reader has to be closed, which also waits for all files to be released.
+func (m *MultipartStream) Open() (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// wait for the previous body to release the files before rewinding them
+	if m.done != nil {
+		<-m.done
+	}
+
+	readers := make([]io.Reader, len(m.files))
+	var closers []io.Closer
+	for i, file := range m.files {
+		reader, closer, err := file.open()
+		if err != nil {
+			for _, c := range closers {
+				_ = c.Close()
+			}
+			return nil, fmt.Errorf("failed to open file %s: %w", file.Name, err)
+		}
+		readers[i] = reader
+		if closer != nil {
+			closers = append(closers, closer)
+		}
+	}
+
+	done := make(chan struct{})
+	m.done = done
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer close(done)
+		err := m.write(pw, readers)
+		for _, c := range closers {
+			err = errors.Join(err, c.Close())
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	return &multipartStreamReader{PipeReader: pr, done: done}, nil
+}
+
+func (m *MultipartStream) write(w io.Writer, readers []io.Reader) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(m.boundary); err != nil {
+		return err
+	}
+
+	part, err := writer.CreatePart(partHeader(`form-data; name="payload_json"`, "application/json"))
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(m.payload); err != nil {
+		return err
+	}
+
+	for i, file := range m.files {
+		part, err = writer.CreatePart(partHeader(fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, file.fileName()), "application/octet-stream"))
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(part, readers[i]); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+type multipartStreamReader struct {
+	*io.PipeReader
+	done chan struct{}
+}
+
+func (r *multipartStreamReader) Close() error {
+	err := r.PipeReader.Close()
+	<-r.done
+	return err
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
 func partHeader(contentDisposition string, contentType string) textproto.MIMEHeader {
 	return textproto.MIMEHeader{
 		"Content-Disposition": []string{contentDisposition},
@@ -104,12 +349,51 @@ func NewFile(name string, description string, reader io.Reader, flags ...FileFla
 	}
 }
 
+// NewFileFunc returns a new File struct with the given name, open func & FileFlags.
+// The open func is called every time the file is sent, which allows resending large files without keeping them in memory.
+func NewFileFunc(name string, description string, open func() (io.ReadCloser, error), flags ...FileFlags) *File {
+	return &File{
+		Name:        name,
+		Description: description,
+		Open:        open,
+		Flags:       FileFlagsNone.Add(flags...),
+	}
+}
+
 // File holds all information about a given io.Reader
 type File struct {
 	Name        string
 	Description string
-	Reader      io.Reader
-	Flags       FileFlags
+	// Reader is read once by PayloadWithFiles. Bodies created with NewMultipartStream keep a copy of it in memory to resend it, unless it is an io.Seeker or Open is set.
+	Reader io.Reader
+	// Open is used instead of Reader to read the file again when a request has to be resent. If Reader is nil, it is also used for the first read.
+	Open  func() (io.ReadCloser, error)
+	Flags FileFlags
+}
+
+// copyTo copies the Reader or, if it is nil, the file returned by Open to w.
+func (f *File) copyTo(w io.Writer) error {
+	if f.Reader != nil {
+		_, err := io.Copy(w, f.Reader)
+		return err
+	}
+	if f.Open == nil {
+		return fmt.Errorf("file %s has neither a Reader nor an Open func", f.Name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(w, rc)
+	return err
+}
+
+func (f *File) fileName() string {
+	if f.Flags.Has(FileFlagSpoiler) {
+		return "SPOILER_" + f.Name
+	}
+	return f.Name
 }
 
 // FileFlags are used to mark Attachments as Spoiler
@@ -0,0 +1,106 @@
+package discord
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+type readerOnly struct {
+	io.Reader
+}
+
+func TestMultipartStream(t *testing.T) {
+	stream, err := NewMultipartStream(MessageCreate{Content: "test"},
+		NewFile("seeker.txt", "", strings.NewReader("seeker data")),
+		NewFile("reader.txt", "", readerOnly{strings.NewReader("reader data")}),
+		NewFileFunc("func.txt", "", func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("func data")), nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var first []byte
+	for i := range 2 {
+		body, err := stream.Open()
+		if err != nil {
+			t.Fatalf("unexpected error opening stream: %v", err)
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatalf("unexpected error reading stream: %v", err)
+		}
+		_ = body.Close()
+
+		for _, expected := range []string{"seeker data", "reader data", "func data"} {
+			if !bytes.Contains(data, []byte(expected)) {
+				t.Errorf("attempt %d: expected body to contain %q", i, expected)
+			}
+		}
+		if i == 0 {
+			first = data
+		} else if !bytes.Equal(first, data) {
+			t.Errorf("expected resent body to be equal to the first one")
+		}
+	}
+}
+
+func TestMultipartStream_Len(t *testing.T) {
+	stream, err := NewMultipartStream(MessageCreate{Content: "test"}, NewFile("file.txt", "", bytes.NewReader([]byte("file data"))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	length := stream.Len()
+	body, err := stream.Open()
+	if err != nil {
+		t.Fatalf("unexpected error opening stream: %v", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("unexpected error reading stream: %v", err)
+	}
+	if int64(len(data)) != length {
+		t.Errorf("expected length %d, got %d", len(data), length)
+	}
+}
+
+func TestPayloadWithFiles_Open(t *testing.T) {
+	closed := false
+	buffer, err := PayloadWithFiles(MessageCreate{Content: "test"},
+		NewFile("reader.txt", "", strings.NewReader("reader data")),
+		NewFileFunc("func.txt", "", func() (io.ReadCloser, error) {
+			return closeFunc{Reader: strings.NewReader("func data"), close: func() { closed = true }}, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{"reader data", "func data"} {
+		if !bytes.Contains(buffer.Buffer.Bytes(), []byte(expected)) {
+			t.Errorf("expected body to contain %q", expected)
+		}
+	}
+	if !closed {
+		t.Error("expected the opened file to be closed")
+	}
+
+	if _, err = PayloadWithFiles(MessageCreate{Content: "test"}, &File{Name: "empty.txt"}); err == nil {
+		t.Error("expected an error for a file without Reader & Open func")
+	}
+}
+
+type closeFunc struct {
+	io.Reader
+	close func()
+}
+
+func (c closeFunc) Close() error {
+	c.close()
+	return nil
+}
@@ -0,0 +1,73 @@
+package discord
+
+import (
+	"github.com/disgoorg/omit"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// GuildWidgetSettings are the settings of the widget of a Guild (https://discord.com/developers/docs/resources/guild#guild-widget-settings-object)
+type GuildWidgetSettings struct {
+	Enabled   bool          `json:"enabled"`
+	ChannelID *snowflake.ID `json:"channel_id"`
+}
+
+// GuildWidgetSettingsUpdate is used to update the GuildWidgetSettings of a Guild
+type GuildWidgetSettingsUpdate struct {
+	Enabled   *bool                    `json:"enabled,omitempty"`
+	ChannelID omit.Omit[*snowflake.ID] `json:"channel_id,omitzero"`
+}
+
+// GuildWidget is the public widget of a Guild (https://discord.com/developers/docs/resources/guild#guild-widget-object)
+type GuildWidget struct {
+	ID            snowflake.ID         `json:"id"`
+	Name          string               `json:"name"`
+	InstantInvite *string              `json:"instant_invite"`
+	Channels      []GuildWidgetChannel `json:"channels"`
+	Members       []GuildWidgetMember  `json:"members"`
+	PresenceCount int                  `json:"presence_count"`
+}
+
+// GuildWidgetChannel is a voice channel shown in the GuildWidget
+type GuildWidgetChannel struct {
+	ID       snowflake.ID `json:"id"`
+	Name     string       `json:"name"`
+	Position int          `json:"position"`
+}
+
+// GuildWidgetMember is an online member shown in the GuildWidget. Its ID is not the ID of the user but an index.
+type GuildWidgetMember struct {
+	ID            snowflake.ID               `json:"id"`
+	Username      string                     `json:"username"`
+	Discriminator string                     `json:"discriminator"`
+	Avatar        *string                    `json:"avatar"`
+	Status        OnlineStatus               `json:"status"`
+	AvatarURL     string                     `json:"avatar_url"`
+	Activity      *GuildWidgetMemberActivity `json:"activity,omitempty"`
+	ChannelID     *snowflake.ID              `json:"channel_id,omitempty"`
+	Deaf          bool                       `json:"deaf,omitempty"`
+	Mute          bool                       `json:"mute,omitempty"`
+	SelfDeaf      bool                       `json:"self_deaf,omitempty"`
+	SelfMute      bool                       `json:"self_mute,omitempty"`
+	Suppress      bool                       `json:"suppress,omitempty"`
+}
+
+// GuildWidgetMemberActivity is the activity of a GuildWidgetMember
+type GuildWidgetMemberActivity struct {
+	Name string `json:"name"`
+}
+
+// GuildWidgetStyle is the style of the image returned by the widget.png endpoint (https://discord.com/developers/docs/resources/guild#get-guild-widget-image-widget-style-options)
+type GuildWidgetStyle string
+
+const (
+	// GuildWidgetStyleShield is a small shield with the guild icon and online count
+	GuildWidgetStyleShield GuildWidgetStyle = "shield"
+	// GuildWidgetStyleBanner1 is a large image with the guild icon, name and online count and a "Powered by Discord" footer
+	GuildWidgetStyleBanner1 GuildWidgetStyle = "banner1"
+	// GuildWidgetStyleBanner2 is a smaller image with the guild icon, name and online count
+	GuildWidgetStyleBanner2 GuildWidgetStyle = "banner2"
+	// GuildWidgetStyleBanner3 is a large image with the guild icon, name and online count and a "Chat Now" footer
+	GuildWidgetStyleBanner3 GuildWidgetStyle = "banner3"
+	// GuildWidgetStyleBanner4 is a large image with a big Discord logo, the guild icon, name and online count and a "Join My Server" button
+	GuildWidgetStyleBanner4 GuildWidgetStyle = "banner4"
+)
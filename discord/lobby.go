@@ -0,0 +1,94 @@
+package discord
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/internal/flags"
+)
+
+// Lobby is a group of users which can communicate with each other (https://discord.com/developers/docs/resources/lobby#lobby-object)
+type Lobby struct {
+	ID            snowflake.ID      `json:"id"`
+	ApplicationID snowflake.ID      `json:"application_id"`
+	Metadata      map[string]string `json:"metadata"`
+	Members       []LobbyMember     `json:"members"`
+	LinkedChannel *LobbyChannel     `json:"linked_channel,omitempty"`
+}
+
+// LobbyChannel is the guild channel a Lobby is linked to
+type LobbyChannel struct {
+	ID      snowflake.ID `json:"id"`
+	Type    ChannelType  `json:"type"`
+	Name    string       `json:"name"`
+	GuildID snowflake.ID `json:"guild_id"`
+}
+
+// LobbyMember is a user in a Lobby (https://discord.com/developers/docs/resources/lobby#lobby-member-object)
+type LobbyMember struct {
+	ID       snowflake.ID      `json:"id"`
+	Metadata map[string]string `json:"metadata"`
+	Flags    LobbyMemberFlags  `json:"flags"`
+}
+
+// LobbyMemberFlags (https://discord.com/developers/docs/resources/lobby#lobby-member-object-lobby-member-flags)
+type LobbyMemberFlags int
+
+// All LobbyMemberFlags
+const (
+	// LobbyMemberFlagCanLinkLobby allows the member to link the Lobby to a channel
+	LobbyMemberFlagCanLinkLobby LobbyMemberFlags = 1 << iota
+	LobbyMemberFlagsNone        LobbyMemberFlags = 0
+)
+
+// Add allows you to add multiple bits together, producing a new bit
+func (f LobbyMemberFlags) Add(bits ...LobbyMemberFlags) LobbyMemberFlags {
+	return flags.Add(f, bits...)
+}
+
+// Remove allows you to subtract multiple bits from the first, producing a new bit
+func (f LobbyMemberFlags) Remove(bits ...LobbyMemberFlags) LobbyMemberFlags {
+	return flags.Remove(f, bits...)
+}
+
+// Has will ensure that the bit includes all the bits entered
+func (f LobbyMemberFlags) Has(bits ...LobbyMemberFlags) bool {
+	return flags.Has(f, bits...)
+}
+
+// Missing will check whether the bit is missing any one of the bits
+func (f LobbyMemberFlags) Missing(bits ...LobbyMemberFlags) bool {
+	return flags.Missing(f, bits...)
+}
+
+// LobbyCreate is used to create a Lobby
+type LobbyCreate struct {
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Members  []LobbyMemberAdd  `json:"members,omitempty"`
+	// IdleTimeoutSeconds is the number of seconds the Lobby may be idle before it's deleted. Must be between 5 and 604800.
+	IdleTimeoutSeconds int `json:"idle_timeout_seconds,omitempty"`
+}
+
+// LobbyUpdate is used to update a Lobby. Members replaces all members of the Lobby.
+type LobbyUpdate struct {
+	Metadata           *map[string]string `json:"metadata,omitempty"`
+	Members            *[]LobbyMemberAdd  `json:"members,omitempty"`
+	IdleTimeoutSeconds *int               `json:"idle_timeout_seconds,omitempty"`
+}
+
+// LobbyMemberAdd is a member of a Lobby in LobbyCreate & LobbyUpdate
+type LobbyMemberAdd struct {
+	ID       snowflake.ID      `json:"id"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Flags    LobbyMemberFlags  `json:"flags,omitempty"`
+}
+
+// LobbyMemberUpdate is used to add a user to a Lobby or update their LobbyMember
+type LobbyMemberUpdate struct {
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Flags    LobbyMemberFlags  `json:"flags,omitempty"`
+}
+
+// LobbyChannelLink is used to link a Lobby to a channel. A nil ChannelID unlinks the Lobby.
+type LobbyChannelLink struct {
+	ChannelID *snowflake.ID `json:"channel_id,omitempty"`
+}
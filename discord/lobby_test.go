@@ -0,0 +1,76 @@
+package discord
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+func TestLobby_JSON(t *testing.T) {
+	data := `{"id":"1","application_id":"2","metadata":{"mode":"ranked"},"members":[{"id":"3","metadata":null,"flags":1}],"linked_channel":{"id":"4","type":0,"name":"general","guild_id":"5"}}`
+
+	var lobby Lobby
+	if err := json.Unmarshal([]byte(data), &lobby); err != nil {
+		t.Fatalf("failed to unmarshal lobby: %v", err)
+	}
+	expected := Lobby{
+		ID:            1,
+		ApplicationID: 2,
+		Metadata:      map[string]string{"mode": "ranked"},
+		Members:       []LobbyMember{{ID: 3, Flags: LobbyMemberFlagCanLinkLobby}},
+		LinkedChannel: &LobbyChannel{ID: 4, Type: ChannelTypeGuildText, Name: "general", GuildID: 5},
+	}
+	if !reflect.DeepEqual(lobby, expected) {
+		t.Errorf("expected %+v, got %+v", expected, lobby)
+	}
+	if !lobby.Members[0].Flags.Has(LobbyMemberFlagCanLinkLobby) {
+		t.Errorf("expected member to be able to link the lobby")
+	}
+
+	marshalled, err := json.Marshal(lobby)
+	if err != nil {
+		t.Fatalf("failed to marshal lobby: %v", err)
+	}
+	var roundTrip Lobby
+	if err = json.Unmarshal(marshalled, &roundTrip); err != nil {
+		t.Fatalf("failed to unmarshal marshalled lobby: %v", err)
+	}
+	if !reflect.DeepEqual(roundTrip, lobby) {
+		t.Errorf("expected %+v after round trip, got %+v", lobby, roundTrip)
+	}
+}
+
+func TestLobby_Payloads(t *testing.T) {
+	channelID := snowflake.ID(4)
+	idleTimeout := 60
+	tests := []struct {
+		name    string
+		payload any
+		want    string
+	}{
+		{name: "empty create", payload: LobbyCreate{}, want: `{}`},
+		{
+			name:    "create",
+			payload: LobbyCreate{Metadata: map[string]string{"mode": "ranked"}, Members: []LobbyMemberAdd{{ID: 3, Flags: LobbyMemberFlagCanLinkLobby}}, IdleTimeoutSeconds: 60},
+			want:    `{"metadata":{"mode":"ranked"},"members":[{"id":"3","flags":1}],"idle_timeout_seconds":60}`,
+		},
+		{name: "empty update", payload: LobbyUpdate{}, want: `{}`},
+		{name: "update removing all members", payload: LobbyUpdate{Members: &[]LobbyMemberAdd{}, IdleTimeoutSeconds: &idleTimeout}, want: `{"members":[],"idle_timeout_seconds":60}`},
+		{name: "member update", payload: LobbyMemberUpdate{Metadata: map[string]string{"team": "red"}}, want: `{"metadata":{"team":"red"}}`},
+		{name: "link channel", payload: LobbyChannelLink{ChannelID: &channelID}, want: `{"channel_id":"4"}`},
+		{name: "unlink channel", payload: LobbyChannelLink{}, want: `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, data)
+			}
+		})
+	}
+}
@@ -175,6 +175,19 @@ func (m Message) JumpURL() string {
 	return fmt.Sprintf(MessageURLFmt, guildID, m.ChannelID, m.ID) // duplicate code, but there isn't a better way without sacrificing user convenience
 }
 
+// IsForward returns whether the message forwards another message, see ForwardedMessage.
+func (m Message) IsForward() bool {
+	return m.MessageReference != nil && m.MessageReference.Type == MessageReferenceTypeForward
+}
+
+// ForwardedMessage returns a snapshot of the message forwarded by this message and whether it is a forward.
+func (m Message) ForwardedMessage() (PartialMessage, bool) {
+	if !m.IsForward() || len(m.MessageSnapshots) == 0 {
+		return PartialMessage{}, false
+	}
+	return m.MessageSnapshots[0].Message, true
+}
+
 type MentionChannel struct {
 	ID      snowflake.ID `json:"id"`
 	GuildID snowflake.ID `json:"guild_id"`
@@ -259,10 +272,12 @@ const (
 	MessageReferenceTypeForward
 )
 
+// MessageSnapshot is a copy of a forwarded message at the time it was forwarded
 type MessageSnapshot struct {
 	Message PartialMessage `json:"message"`
 }
 
+// PartialMessage is the content of a message in a MessageSnapshot
 type PartialMessage struct {
 	Type            MessageType       `json:"type"`
 	Content         string            `json:"content,omitempty"`
@@ -66,7 +66,7 @@ func (MessageCreate) interactionCallbackData() {}
 func (m MessageCreate) ToBody() (any, error) {
 	if len(m.Files) > 0 {
 		m.Attachments = parseAttachments(m.Files)
-		return PayloadWithFiles(m, m.Files...)
+		return NewMultipartStream(m, m.Files...)
 	}
 	return m, nil
 }
@@ -75,7 +75,7 @@ func (m MessageCreate) ToResponseBody(response InteractionResponse) (any, error)
 	if len(m.Files) > 0 {
 		m.Attachments = parseAttachments(m.Files)
 		response.Data = m
-		return PayloadWithFiles(response, m.Files...)
+		return NewMultipartStream(response, m.Files...)
 	}
 	return response, nil
 }
@@ -282,6 +282,17 @@ func (m MessageCreate) WithMessageReferenceByID(messageID snowflake.ID) MessageC
 	return m
 }
 
+// WithMessageForward returns a new MessageCreate with a MessageReference forwarding the provided Message.
+// A forward can't have any content besides the forwarded Message.
+func (m MessageCreate) WithMessageForward(channelID snowflake.ID, messageID snowflake.ID) MessageCreate {
+	m.MessageReference = &MessageReference{
+		Type:      MessageReferenceTypeForward,
+		MessageID: &messageID,
+		ChannelID: &channelID,
+	}
+	return m
+}
+
 // WithFlags returns a new MessageCreate with the provided message flags.
 func (m MessageCreate) WithFlags(flags ...MessageFlags) MessageCreate {
 	m.Flags = m.Flags.Add(flags...)
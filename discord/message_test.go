@@ -0,0 +1,35 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+)
+
+func TestMessage_ForwardedMessage(t *testing.T) {
+	data := `{
+		"id": "3",
+		"channel_id": "2",
+		"type": 0,
+		"flags": 16384,
+		"message_reference": {"type": 1, "message_id": "1", "channel_id": "1"},
+		"message_snapshots": [{"message": {"type": 0, "content": "forwarded", "attachments": [], "flags": 0}}]
+	}`
+
+	var message Message
+	if err := json.Unmarshal([]byte(data), &message); err != nil {
+		t.Fatalf("unexpected error unmarshaling: %v", err)
+	}
+	if !message.IsForward() {
+		t.Fatalf("expected message to be a forward")
+	}
+	forwarded, ok := message.ForwardedMessage()
+	if !ok || forwarded.Content != "forwarded" {
+		t.Errorf("expected forwarded content, got %+v", forwarded)
+	}
+
+	message.MessageReference.Type = MessageReferenceTypeDefault
+	if _, ok = message.ForwardedMessage(); ok {
+		t.Errorf("expected a reply to not be a forward")
+	}
+}
@@ -69,7 +69,7 @@ func (m MessageUpdate) ToBody() (any, error) {
 			}
 			*m.Attachments = append(*m.Attachments, attachmentCreate)
 		}
-		return PayloadWithFiles(m, m.Files...)
+		return NewMultipartStream(m, m.Files...)
 	}
 	return m, nil
 }
@@ -82,7 +82,7 @@ func (m MessageUpdate) ToResponseBody(response InteractionResponse) (any, error)
 			}
 			*m.Attachments = append(*m.Attachments, attachmentCreate)
 		}
-		return PayloadWithFiles(response, m.Files...)
+		return NewMultipartStream(response, m.Files...)
 	}
 	return response, nil
 }
@@ -0,0 +1,435 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of Discord which are checked by the Validate methods.
+const (
+	messageContentMaxLength      = 2000
+	messageMaxEmbeds             = 10
+	messageMaxFiles              = 10
+	messageMaxStickers           = 3
+	messageMaxActionRows         = 5
+	messageMaxComponents         = 40
+	messageMaxTextDisplayLength  = 4000
+	embedMaxTotalLength          = 6000
+	embedTitleMaxLength          = 256
+	embedDescriptionMaxLength    = 4096
+	embedMaxFields               = 25
+	embedFieldNameMaxLength      = 256
+	embedFieldValueMaxLength     = 1024
+	embedFooterTextMaxLength     = 2048
+	embedAuthorNameMaxLength     = 256
+	actionRowMaxComponents       = 5
+	sectionMaxComponents         = 3
+	mediaGalleryMaxItems         = 10
+	customIDMaxLength            = 100
+	buttonLabelMaxLength         = 80
+	labelMaxLength               = 45
+	modalTitleMaxLength          = 45
+	modalMaxComponents           = 5
+	pollMaxAnswers               = 10
+	autocompleteMaxChoices       = 25
+	webhookUsernameMaxLength     = 80
+	threadNameMaxLength          = 100
+	pollQuestionTextMaxLength    = 300
+	pollAnswerTextMaxLength      = 55
+	textInputValueMaxLength      = 4000
+	selectMenuMaxOptionsOrValues = 25
+)
+
+// Validator is implemented by payloads which can be checked against the limits of Discord before they are sent.
+type Validator interface {
+	// Validate returns ValidationErrors with all fields exceeding a limit of Discord or nil if the payload is valid.
+	Validate() error
+}
+
+var (
+	_ Validator = (*MessageCreate)(nil)
+	_ Validator = (*MessageUpdate)(nil)
+	_ Validator = (*WebhookMessageCreate)(nil)
+	_ Validator = (*ModalCreate)(nil)
+	_ Validator = (*InteractionResponse)(nil)
+)
+
+// ValidationError is a field of a payload which would be rejected by Discord.
+type ValidationError struct {
+	// Path is the path to the field using its json names, e.g. embeds[0].fields[2].value
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors are all ValidationError(s) of a payload.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	errs := make([]string, len(e))
+	for i, err := range e {
+		errs[i] = err.Error()
+	}
+	return "invalid payload: " + strings.Join(errs, ", ")
+}
+
+// Unwrap returns the ValidationError(s) for use with errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// validator collects ValidationError(s). The prefix is prepended to all paths.
+type validator struct {
+	prefix string
+	errs   ValidationErrors
+}
+
+func (v *validator) addf(path string, format string, a ...any) {
+	v.errs = append(v.errs, ValidationError{
+		Path:    v.prefix + path,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+func (v *validator) maxLength(path string, s string, maxLength int) {
+	if length := utf8.RuneCountInString(s); length > maxLength {
+		v.addf(path, "must be at most %d characters long, got %d", maxLength, length)
+	}
+}
+
+func (v *validator) maxCount(path string, count int, maxCount int) {
+	if count > maxCount {
+		v.addf(path, "must contain at most %d elements, got %d", maxCount, count)
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// Validate checks the MessageCreate against the limits of Discord.
+func (m MessageCreate) Validate() error {
+	var v validator
+	m.validate(&v)
+	return v.err()
+}
+
+func (m MessageCreate) validate(v *validator) {
+	v.maxLength("content", m.Content, messageContentMaxLength)
+	validateEmbeds(v, m.Embeds)
+	validateMessageComponents(v, m.Components, m.Flags.Has(MessageFlagIsComponentsV2))
+	v.maxCount("sticker_ids", len(m.StickerIDs), messageMaxStickers)
+	v.maxCount("files", len(m.Files), messageMaxFiles)
+	validatePoll(v, m.Poll)
+	if m.Flags.Has(MessageFlagIsComponentsV2) {
+		validateNoComponentsV2Content(v, m.Content != "", len(m.Embeds) > 0, len(m.StickerIDs) > 0, m.Poll != nil)
+	}
+}
+
+// Validate checks the MessageUpdate against the limits of Discord.
+// If Flags is nil, MessageFlagIsComponentsV2 is assumed when Components contains a component other than ActionRowComponent.
+func (m MessageUpdate) Validate() error {
+	var v validator
+	m.validate(&v)
+	return v.err()
+}
+
+func (m MessageUpdate) validate(v *validator) {
+	var componentsV2 bool
+	if m.Flags != nil {
+		componentsV2 = m.Flags.Has(MessageFlagIsComponentsV2)
+	} else if m.Components != nil {
+		componentsV2 = hasComponentsV2(*m.Components)
+	}
+
+	if m.Content != nil {
+		v.maxLength("content", *m.Content, messageContentMaxLength)
+	}
+	if m.Embeds != nil {
+		validateEmbeds(v, *m.Embeds)
+	}
+	if m.Components != nil {
+		validateMessageComponents(v, *m.Components, componentsV2)
+	}
+	v.maxCount("files", len(m.Files), messageMaxFiles)
+	if componentsV2 {
+		validateNoComponentsV2Content(v, m.Content != nil && *m.Content != "", m.Embeds != nil && len(*m.Embeds) > 0, false, false)
+	}
+}
+
+// Validate checks the WebhookMessageCreate against the limits of Discord.
+func (m WebhookMessageCreate) Validate() error {
+	var v validator
+	v.maxLength("content", m.Content, messageContentMaxLength)
+	v.maxLength("username", m.Username, webhookUsernameMaxLength)
+	v.maxLength("thread_name", m.ThreadName, threadNameMaxLength)
+	validateEmbeds(&v, m.Embeds)
+	validateMessageComponents(&v, m.Components, m.Flags.Has(MessageFlagIsComponentsV2))
+	v.maxCount("files", len(m.Files), messageMaxFiles)
+	validatePoll(&v, m.Poll)
+	if m.Flags.Has(MessageFlagIsComponentsV2) {
+		validateNoComponentsV2Content(&v, m.Content != "", len(m.Embeds) > 0, false, m.Poll != nil)
+	}
+	return v.err()
+}
+
+// Validate checks the ModalCreate against the limits of Discord.
+func (m ModalCreate) Validate() error {
+	var v validator
+	m.validate(&v)
+	return v.err()
+}
+
+func (m ModalCreate) validate(v *validator) {
+	if m.CustomID == "" {
+		v.addf("custom_id", "must not be empty")
+	}
+	v.maxLength("custom_id", m.CustomID, customIDMaxLength)
+	if m.Title == "" {
+		v.addf("title", "must not be empty")
+	}
+	v.maxLength("title", m.Title, modalTitleMaxLength)
+
+	if len(m.Components) == 0 {
+		v.addf("components", "must not be empty")
+	}
+	v.maxCount("components", len(m.Components), modalMaxComponents)
+	for i, c := range m.Components {
+		path := fmt.Sprintf("components[%d]", i)
+		switch c.(type) {
+		case ActionRowComponent, LabelComponent, TextDisplayComponent:
+		default:
+			v.addf(path, "%T is not supported as top level component in modals", c)
+			continue
+		}
+		validateComponent(v, path, c, true)
+	}
+}
+
+// Validate checks the data of the InteractionResponse against the limits of Discord.
+func (r InteractionResponse) Validate() error {
+	v := validator{prefix: "data."}
+	switch d := r.Data.(type) {
+	case MessageCreate:
+		d.validate(&v)
+	case MessageUpdate:
+		d.validate(&v)
+	case ModalCreate:
+		d.validate(&v)
+	case AutocompleteResult:
+		v.maxCount("choices", len(d.Choices), autocompleteMaxChoices)
+	}
+	return v.err()
+}
+
+func validateEmbeds(v *validator, embeds []Embed) {
+	v.maxCount("embeds", len(embeds), messageMaxEmbeds)
+
+	var total int
+	for i, embed := range embeds {
+		path := fmt.Sprintf("embeds[%d]", i)
+		v.maxLength(path+".title", embed.Title, embedTitleMaxLength)
+		v.maxLength(path+".description", embed.Description, embedDescriptionMaxLength)
+		total += utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+
+		v.maxCount(path+".fields", len(embed.Fields), embedMaxFields)
+		for j, field := range embed.Fields {
+			fieldPath := fmt.Sprintf("%s.fields[%d]", path, j)
+			v.maxLength(fieldPath+".name", field.Name, embedFieldNameMaxLength)
+			v.maxLength(fieldPath+".value", field.Value, embedFieldValueMaxLength)
+			total += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+		}
+		if embed.Footer != nil {
+			v.maxLength(path+".footer.text", embed.Footer.Text, embedFooterTextMaxLength)
+			total += utf8.RuneCountInString(embed.Footer.Text)
+		}
+		if embed.Author != nil {
+			v.maxLength(path+".author.name", embed.Author.Name, embedAuthorNameMaxLength)
+			total += utf8.RuneCountInString(embed.Author.Name)
+		}
+	}
+	if total > embedMaxTotalLength {
+		v.addf("embeds", "must contain at most %d characters in total, got %d", embedMaxTotalLength, total)
+	}
+}
+
+func validatePoll(v *validator, poll *PollCreate) {
+	if poll == nil {
+		return
+	}
+	if poll.Question.Text != nil {
+		v.maxLength("poll.question.text", *poll.Question.Text, pollQuestionTextMaxLength)
+	}
+	v.maxCount("poll.answers", len(poll.Answers), pollMaxAnswers)
+	for i, answer := range poll.Answers {
+		if answer.Text != nil {
+			v.maxLength(fmt.Sprintf("poll.answers[%d].poll_media.text", i), *answer.Text, pollAnswerTextMaxLength)
+		}
+	}
+}
+
+func validateNoComponentsV2Content(v *validator, content bool, embeds bool, stickers bool, poll bool) {
+	for _, field := range []struct {
+		name string
+		set  bool
+	}{
+		{"content", content},
+		{"embeds", embeds},
+		{"sticker_ids", stickers},
+		{"poll", poll},
+	} {
+		if field.set {
+			v.addf(field.name, "cannot be used with MessageFlagIsComponentsV2")
+		}
+	}
+}
+
+func hasComponentsV2(components []LayoutComponent) bool {
+	for _, c := range components {
+		if _, ok := c.(ActionRowComponent); !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func validateMessageComponents(v *validator, components []LayoutComponent, componentsV2 bool) {
+	if !componentsV2 {
+		v.maxCount("components", len(components), messageMaxActionRows)
+	}
+	for i, c := range components {
+		path := fmt.Sprintf("components[%d]", i)
+		if _, ok := c.(ActionRowComponent); !ok && !componentsV2 {
+			v.addf(path, "%T requires MessageFlagIsComponentsV2", c)
+			continue
+		}
+		validateComponent(v, path, c, false)
+	}
+	if !componentsV2 {
+		return
+	}
+
+	var count, textLength int
+	for c := range componentIter(components) {
+		count++
+		if textDisplay, ok := c.(TextDisplayComponent); ok {
+			textLength += utf8.RuneCountInString(textDisplay.Content)
+		}
+	}
+	if count > messageMaxComponents {
+		v.addf("components", "must contain at most %d components including nested ones, got %d", messageMaxComponents, count)
+	}
+	if textLength > messageMaxTextDisplayLength {
+		v.addf("components", "text displays must contain at most %d characters in total, got %d", messageMaxTextDisplayLength, textLength)
+	}
+}
+
+// validateComponent checks the component and its sub components. modal decides whether modal only components are allowed.
+func validateComponent(v *validator, path string, c Component, modal bool) {
+	if ic, ok := c.(InteractiveComponent); ok {
+		v.maxLength(path+".custom_id", ic.GetCustomID(), customIDMaxLength)
+	}
+
+	switch c := c.(type) {
+	case ActionRowComponent:
+		if len(c.Components) == 0 {
+			v.addf(path+".components", "must not be empty")
+		}
+		v.maxCount(path+".components", len(c.Components), actionRowMaxComponents)
+		for i, cc := range c.Components {
+			ccPath := fmt.Sprintf("%s.components[%d]", path, i)
+			switch cc.(type) {
+			case ButtonComponent:
+			default:
+				if len(c.Components) > 1 {
+					v.addf(ccPath, "%T must be the only component in an action row", cc)
+				}
+			}
+			validateComponent(v, ccPath, cc, modal)
+		}
+
+	case ButtonComponent:
+		if modal {
+			v.addf(path, "buttons are not supported in modals")
+		}
+		v.maxLength(path+".label", c.Label, buttonLabelMaxLength)
+		switch c.Style {
+		case ButtonStyleLink:
+			if c.URL == "" {
+				v.addf(path+".url", "must be set for link buttons")
+			}
+		case ButtonStylePremium:
+			if c.SkuID == 0 {
+				v.addf(path+".sku_id", "must be set for premium buttons")
+			}
+		default:
+			if c.CustomID == "" {
+				v.addf(path+".custom_id", "must be set for non link & premium buttons")
+			}
+		}
+
+	case StringSelectMenuComponent:
+		v.maxCount(path+".options", len(c.Options), selectMenuMaxOptionsOrValues)
+		if c.MaxValues > selectMenuMaxOptionsOrValues {
+			v.addf(path+".max_values", "must be at most %d, got %d", selectMenuMaxOptionsOrValues, c.MaxValues)
+		}
+
+	case TextInputComponent:
+		if !modal {
+			v.addf(path, "text inputs are only supported in modals")
+		}
+		v.maxLength(path+".value", c.Value, textInputValueMaxLength)
+
+	case SectionComponent:
+		if len(c.Components) == 0 {
+			v.addf(path+".components", "must not be empty")
+		}
+		v.maxCount(path+".components", len(c.Components), sectionMaxComponents)
+		for i, cc := range c.Components {
+			validateComponent(v, fmt.Sprintf("%s.components[%d]", path, i), cc, modal)
+		}
+		if c.Accessory == nil {
+			v.addf(path+".accessory", "must be set")
+		} else {
+			validateComponent(v, path+".accessory", c.Accessory, modal)
+		}
+
+	case MediaGalleryComponent:
+		if len(c.Items) == 0 {
+			v.addf(path+".items", "must not be empty")
+		}
+		v.maxCount(path+".items", len(c.Items), mediaGalleryMaxItems)
+
+	case ContainerComponent:
+		for i, cc := range c.Components {
+			validateComponent(v, fmt.Sprintf("%s.components[%d]", path, i), cc, modal)
+		}
+
+	case LabelComponent:
+		if !modal {
+			v.addf(path, "labels are only supported in modals")
+		}
+		v.maxLength(path+".label", c.Label, labelMaxLength)
+		if c.Component == nil {
+			v.addf(path+".component", "must be set")
+		} else {
+			validateComponent(v, path+".component", c.Component, modal)
+		}
+
+	case FileUploadComponent, RadioGroupComponent, CheckboxGroupComponent, CheckboxComponent:
+		if !modal {
+			v.addf(path, "%T is only supported in modals", c)
+		}
+	}
+}
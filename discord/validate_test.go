@@ -0,0 +1,81 @@
+package discord
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func validationPaths(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %T", err)
+	}
+	paths := make([]string, len(errs))
+	for i, e := range errs {
+		paths[i] = e.Path
+	}
+	return paths
+}
+
+func TestMessageCreate_Validate(t *testing.T) {
+	if err := NewMessageCreate().WithContent("hello").Validate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := make([]EmbedField, 5)
+	for i := range fields {
+		fields[i] = EmbedField{Name: "name", Value: strings.Repeat("a", 1024)}
+	}
+	messageCreate := MessageCreate{
+		Content: strings.Repeat("a", 2001),
+		Embeds:  []Embed{{Fields: fields}, {Fields: fields}},
+		Components: []LayoutComponent{
+			NewActionRow(NewPrimaryButton("label", "id"), NewLinkButton("label", "")),
+			NewTextDisplay("text"),
+		},
+	}
+	got := validationPaths(t, messageCreate.Validate())
+	want := []string{"content", "embeds", "components[0].components[1].url", "components[1]"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected paths %v, got %v", want, got)
+	}
+}
+
+func TestMessageCreate_ValidateComponentsV2(t *testing.T) {
+	messageCreate := MessageCreate{
+		Content: "content",
+		Flags:   MessageFlagIsComponentsV2,
+		Components: []LayoutComponent{
+			NewContainer(
+				SectionComponent{Components: []SectionSubComponent{NewTextDisplay("text")}},
+			),
+		},
+	}
+	got := validationPaths(t, messageCreate.Validate())
+	want := []string{"components[0].components[0].accessory", "content"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected paths %v, got %v", want, got)
+	}
+}
+
+func TestInteractionResponse_Validate(t *testing.T) {
+	response := InteractionResponse{
+		Type: InteractionResponseTypeModal,
+		Data: ModalCreate{
+			CustomID:   "modal",
+			Title:      strings.Repeat("a", 46),
+			Components: []LayoutComponent{NewActionRow(NewPrimaryButton("label", "id"))},
+		},
+	}
+	got := validationPaths(t, response.Validate())
+	want := []string{"data.title", "data.components[0].components[0]"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected paths %v, got %v", want, got)
+	}
+}
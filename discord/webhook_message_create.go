@@ -62,7 +62,7 @@ func (m *WebhookMessageCreate) UnmarshalJSON(data []byte) error {
 func (m WebhookMessageCreate) ToBody() (any, error) {
 	if len(m.Files) > 0 {
 		m.Attachments = parseAttachments(m.Files)
-		return PayloadWithFiles(m, m.Files...)
+		return NewMultipartStream(m, m.Files...)
 	}
 	return m, nil
 }
@@ -68,7 +68,7 @@ func (m WebhookMessageUpdate) ToBody() (any, error) {
 			}
 			*m.Attachments = append(*m.Attachments, attachmentCreate)
 		}
-		return PayloadWithFiles(m, m.Files...)
+		return NewMultipartStream(m, m.Files...)
 	}
 	return m, nil
 }
@@ -19,6 +19,16 @@ type DMMessageCreate struct {
 	*GenericDMMessage
 }
 
+// IsForward returns whether the message forwards another message.
+func (e *DMMessageCreate) IsForward() bool {
+	return e.Message.IsForward()
+}
+
+// ForwardedMessage returns a snapshot of the forwarded message and whether the message is a forward.
+func (e *DMMessageCreate) ForwardedMessage() (discord.PartialMessage, bool) {
+	return e.Message.ForwardedMessage()
+}
+
 // DMMessageUpdate is called upon editing a discord.Message in a Channel (requires gateway.IntentsDirectMessage)
 type DMMessageUpdate struct {
 	*GenericDMMessage
@@ -31,6 +31,16 @@ type GuildMessageCreate struct {
 	*GenericGuildMessage
 }
 
+// IsForward returns whether the message forwards another message.
+func (e *GuildMessageCreate) IsForward() bool {
+	return e.Message.IsForward()
+}
+
+// ForwardedMessage returns a snapshot of the forwarded message and whether the message is a forward.
+func (e *GuildMessageCreate) ForwardedMessage() (discord.PartialMessage, bool) {
+	return e.Message.ForwardedMessage()
+}
+
 // GuildMessageUpdate is called upon editing a discord.Message in a Channel
 type GuildMessageUpdate struct {
 	*GenericGuildMessage
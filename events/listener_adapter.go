@@ -17,6 +17,9 @@ type ListenerAdapter struct {
 	// heartbeat ack event
 	OnHeartbeatAck func(event *HeartbeatAck)
 
+	// reconnect attempt event
+	OnReconnectAttempt func(event *ReconnectAttempt)
+
 	// gateway ratelimited event
 	OnGatewayRateLimited func(event *GatewayRateLimited)
 
@@ -224,6 +227,11 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 			listener(e)
 		}
 
+	case *ReconnectAttempt:
+		if listener := l.OnReconnectAttempt; listener != nil {
+			listener(e)
+		}
+
 	case *GatewayRateLimited:
 		if listener := l.OnGatewayRateLimited; listener != nil {
 			listener(e)
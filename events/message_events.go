@@ -33,6 +33,16 @@ type MessageCreate struct {
 	*GenericMessage
 }
 
+// IsForward returns whether the message forwards another message.
+func (e *MessageCreate) IsForward() bool {
+	return e.Message.IsForward()
+}
+
+// ForwardedMessage returns a snapshot of the forwarded message and whether the message is a forward.
+func (e *MessageCreate) ForwardedMessage() (discord.PartialMessage, bool) {
+	return e.Message.ForwardedMessage()
+}
+
 // MessageUpdate indicates that a discord.Message got update
 type MessageUpdate struct {
 	*GenericMessage
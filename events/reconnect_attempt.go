@@ -0,0 +1,9 @@
+package events
+
+import "github.com/disgoorg/disgo/gateway"
+
+// ReconnectAttempt is dispatched before the gateway.Gateway of a shard tries to reconnect.
+type ReconnectAttempt struct {
+	*GenericEvent
+	gateway.EventReconnectAttempt
+}
@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// ErrMaxReconnectAttempts is returned when a Gateway could not (re)connect within BackoffPolicy.MaxAttempts.
+var ErrMaxReconnectAttempts = errors.New("maximum reconnect attempts reached")
+
+// DefaultBackoffPolicy returns the BackoffPolicy used by the Gateway by default.
+func DefaultBackoffPolicy() BackoffPolicy {
+	return BackoffPolicy{
+		Base:   time.Second,
+		Max:    60 * time.Second,
+		Jitter: 0.2,
+	}
+}
+
+// BackoffPolicy decides how long a gateway waits before each (re)connect attempt and when it gives up.
+// The delay starts at Base and doubles with each failed attempt up to Max.
+// Zero Base & Max are replaced with the defaults when the BackoffPolicy is passed to WithBackoffPolicy, see WithDefaults.
+type BackoffPolicy struct {
+	// Base is the delay before the first attempt.
+	Base time.Duration
+	// Max caps the delay. A negative value means no cap.
+	Max time.Duration
+	// Jitter is the fraction (0-1) of the delay which is randomly subtracted, so gateways don't reconnect in lockstep after an outage.
+	Jitter float64
+	// MaxAttempts is the number of failed attempts after which the gateway gives up. 0 means retrying forever.
+	MaxAttempts int
+	// CloseCodes overrides whether the gateway reconnects after receiving a close code.
+	// Close codes which are not in the map use the Reconnect field of the close code.
+	CloseCodes map[int]bool
+}
+
+// Delay returns the delay before the attempt, starting at 0.
+func (p BackoffPolicy) Delay(attempt int) time.Duration {
+	delay := p.Base
+	for range attempt {
+		if p.Max > 0 && delay >= p.Max {
+			break
+		}
+		// stop doubling before the duration overflows
+		if delay >= math.MaxInt64/2 {
+			break
+		}
+		delay *= 2
+	}
+	if p.Max > 0 && delay > p.Max {
+		delay = p.Max
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * min(p.Jitter, 1) * float64(delay))
+	}
+	return delay
+}
+
+// WithDefaults returns a copy of the BackoffPolicy with a zero Base or Max replaced by the one of defaults.
+// Without this, a partially filled BackoffPolicy would reconnect all gateways without any delay.
+func (p BackoffPolicy) WithDefaults(defaults BackoffPolicy) BackoffPolicy {
+	if p.Base == 0 {
+		p.Base = defaults.Base
+	}
+	if p.Max == 0 {
+		p.Max = defaults.Max
+	}
+	return p
+}
+
+// Reconnect returns whether to reconnect after receiving the close code.
+// reconnect is the default behavior of the close code.
+func (p BackoffPolicy) Reconnect(code int, reconnect bool) bool {
+	if override, ok := p.CloseCodes[code]; ok {
+		return override
+	}
+	return reconnect
+}
+
+// Exhausted returns whether no more attempts are allowed after the given number of failed attempts.
+func (p BackoffPolicy) Exhausted(failedAttempts int) bool {
+	return p.MaxAttempts > 0 && failedAttempts >= p.MaxAttempts
+}
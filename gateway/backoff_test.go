@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	p := BackoffPolicy{Base: time.Second, Max: 10 * time.Second}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		if got := p.Delay(attempt); got != want {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, want, got)
+		}
+	}
+	if got := (BackoffPolicy{Base: time.Second}).Delay(1000); got <= 0 {
+		t.Errorf("expected uncapped delay not to overflow, got %s", got)
+	}
+	if got := (BackoffPolicy{Base: 1 << 62}).Delay(2); got <= 0 {
+		t.Errorf("expected uncapped power of two delay not to overflow, got %s", got)
+	}
+
+	p.Jitter = 0.5
+	for range 100 {
+		if got := p.Delay(3); got <= 4*time.Second || got > 8*time.Second {
+			t.Fatalf("expected jittered delay in (4s, 8s], got %s", got)
+		}
+	}
+}
+
+func TestBackoffPolicy_Reconnect(t *testing.T) {
+	t.Parallel()
+
+	p := BackoffPolicy{CloseCodes: map[int]bool{CloseEventCodeShardingRequired.Code: true, CloseEventCodeUnknownError.Code: false}}
+	if !p.Reconnect(CloseEventCodeShardingRequired.Code, CloseEventCodeShardingRequired.Reconnect) {
+		t.Error("expected override to reconnect")
+	}
+	if p.Reconnect(CloseEventCodeUnknownError.Code, CloseEventCodeUnknownError.Reconnect) {
+		t.Error("expected override to not reconnect")
+	}
+	if !p.Reconnect(CloseEventCodeSessionTimed.Code, CloseEventCodeSessionTimed.Reconnect) {
+		t.Error("expected default behavior of close code")
+	}
+
+	if p.Exhausted(100) {
+		t.Error("expected unlimited attempts")
+	}
+	p.MaxAttempts = 2
+	if p.Exhausted(1) || !p.Exhausted(2) {
+		t.Error("expected exhaustion after 2 attempts")
+	}
+}
+
+func TestBackoffPolicy_WithDefaults(t *testing.T) {
+	t.Parallel()
+
+	p := BackoffPolicy{MaxAttempts: 5}.WithDefaults(DefaultBackoffPolicy())
+	if p.Base != time.Second || p.Max != 60*time.Second || p.MaxAttempts != 5 {
+		t.Errorf("expected zero base & max to be filled from defaults, got %+v", p)
+	}
+
+	p = BackoffPolicy{Base: time.Millisecond, Max: -1}.WithDefaults(DefaultBackoffPolicy())
+	if p.Base != time.Millisecond || p.Max != -1 {
+		t.Errorf("expected set base & max to be kept, got %+v", p)
+	}
+}
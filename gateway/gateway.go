@@ -25,8 +25,6 @@ const Version = 10
 // URL is the default URL used to connect to the Discord gateway.
 const URL = "wss://gateway.discord.gg"
 
-const maximumConnectDelay = 60 * time.Second
-
 // Status is the state that the client is currently in.
 type Status int
 
@@ -129,6 +127,7 @@ type Gateway interface {
 	Open(ctx context.Context) error
 
 	// Close gracefully closes the Gateway with the websocket.CloseNormalClosure code.
+	// If a SessionStore is set, the Gateway closes with the websocket.CloseServiceRestart code instead to keep the stored session resumable.
 	// If the context is done, the Gateway connection will be killed.
 	Close(ctx context.Context)
 
@@ -171,12 +170,19 @@ type gatewayImpl struct {
 	eventHandlerFunc EventHandlerFunc
 	token            string
 
-	conn            transport
-	connMu          sync.Mutex
-	heartbeatCancel context.CancelFunc
-	status          Status
-	statusMu        sync.Mutex
+	conn     transport
+	connMu   sync.Mutex
+	status   Status
+	statusMu sync.Mutex
+
+	// sessionMu guards the session of the config (SessionID, LastSequenceReceived & ResumeURL)
+	sessionMu sync.Mutex
+	// storeMu serializes writes to the SessionStore, so an older session never overwrites a newer one
+	storeMu sync.Mutex
 
+	// heartbeatMu guards the heartbeat state, which is shared by the listen & heartbeat goroutines
+	heartbeatMu           sync.Mutex
+	heartbeatCancel       context.CancelFunc
 	heartbeatInterval     time.Duration
 	lastHeartbeatSent     time.Time
 	lastHeartbeatReceived time.Time
@@ -191,14 +197,20 @@ func (g *gatewayImpl) ShardCount() int {
 }
 
 func (g *gatewayImpl) SessionID() *string {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
 	return g.config.SessionID
 }
 
 func (g *gatewayImpl) LastSequenceReceived() *int {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
 	return g.config.LastSequenceReceived
 }
 
 func (g *gatewayImpl) ResumeURL() *string {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
 	return g.config.ResumeURL
 }
 
@@ -207,7 +219,80 @@ func (g *gatewayImpl) Intents() Intents {
 }
 
 func (g *gatewayImpl) Open(ctx context.Context) error {
-	return g.doReconnect(ctx)
+	if g.config.SessionStore != nil && g.SessionID() == nil {
+		g.loadSession(ctx)
+	}
+	return g.doReconnect(ctx, false)
+}
+
+// loadSession loads the session from the SessionStore if it was created with the same shard count.
+func (g *gatewayImpl) loadSession(ctx context.Context) {
+	session, err := g.config.SessionStore.Get(ctx, g.config.ShardID)
+	if err != nil {
+		g.config.Logger.ErrorContext(ctx, "failed to load session", slog.Any("err", err))
+		return
+	}
+	if session == nil || session.ID == "" {
+		return
+	}
+	if session.ShardCount != g.config.ShardCount {
+		g.config.Logger.DebugContext(ctx, "ignoring stored session of different shard count", slog.Int("session_shard_count", session.ShardCount))
+		return
+	}
+
+	g.config.Logger.DebugContext(ctx, "loaded stored session", slog.String("session_id", session.ID), slog.Int("sequence", session.Sequence))
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
+	g.config.SessionID = &session.ID
+	g.config.LastSequenceReceived = &session.Sequence
+	if session.ResumeURL != "" {
+		g.config.ResumeURL = &session.ResumeURL
+	}
+}
+
+// session returns the current session ID, last sequence received & resume URL.
+func (g *gatewayImpl) session() (*string, *int, *string) {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
+	return g.config.SessionID, g.config.LastSequenceReceived, g.config.ResumeURL
+}
+
+// clearSession clears the session, so the next connection identifies instead of resuming.
+func (g *gatewayImpl) clearSession() {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
+	g.config.SessionID = nil
+	g.config.LastSequenceReceived = nil
+	g.config.ResumeURL = nil
+}
+
+// storeSession stores the current session in the SessionStore or deletes it if there is no resumable session.
+func (g *gatewayImpl) storeSession(ctx context.Context) {
+	if g.config.SessionStore == nil {
+		return
+	}
+
+	g.storeMu.Lock()
+	defer g.storeMu.Unlock()
+	sessionID, sequence, resumeURL := g.session()
+
+	var err error
+	if sessionID == nil || sequence == nil {
+		err = g.config.SessionStore.Delete(ctx, g.config.ShardID)
+	} else {
+		session := Session{
+			ID:         *sessionID,
+			Sequence:   *sequence,
+			ShardCount: g.config.ShardCount,
+		}
+		if resumeURL != nil {
+			session.ResumeURL = *resumeURL
+		}
+		err = g.config.SessionStore.Set(ctx, g.config.ShardID, session)
+	}
+	if err != nil {
+		g.config.Logger.ErrorContext(ctx, "failed to store session", slog.Any("err", err))
+	}
 }
 
 func (g *gatewayImpl) open(ctx context.Context) error {
@@ -222,7 +307,8 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 	g.status = StatusConnecting
 	g.statusMu.Unlock()
 
-	if g.config.LastSequenceReceived == nil || g.config.SessionID == nil {
+	sessionID, sequence, resumeURL := g.session()
+	if sequence == nil || sessionID == nil {
 		if err := g.config.IdentifyRateLimiter.Wait(ctx, g.config.ShardID); err != nil {
 			g.config.Logger.ErrorContext(ctx, "failed to wait for identify rate limiter", slog.Any("err", err))
 			g.connMu.Unlock()
@@ -232,8 +318,8 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 	}
 
 	wsURL := g.config.URL
-	if g.config.ResumeURL != nil && g.config.EnableResumeURL {
-		wsURL = *g.config.ResumeURL
+	if resumeURL != nil && g.config.EnableResumeURL {
+		wsURL = *resumeURL
 	}
 
 	values := url.Values{}
@@ -246,7 +332,9 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 
 	gatewayURL := wsURL + "?" + values.Encode()
 
+	g.heartbeatMu.Lock()
 	g.lastHeartbeatSent = time.Now()
+	g.heartbeatMu.Unlock()
 	conn, rs, err := g.config.Dialer.DialContext(ctx, gatewayURL, nil)
 	if err != nil {
 		var body []byte
@@ -310,13 +398,22 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 }
 
 func (g *gatewayImpl) Close(ctx context.Context) {
+	if g.config.SessionStore != nil {
+		// a normal closure invalidates the session, so keep it to resume after the restart
+		g.CloseWithCode(ctx, websocket.CloseServiceRestart, "Restarting")
+		return
+	}
 	g.CloseWithCode(ctx, websocket.CloseNormalClosure, "Shutting down")
 }
 
 func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message string) {
-	if g.heartbeatCancel != nil {
+	g.heartbeatMu.Lock()
+	heartbeatCancel := g.heartbeatCancel
+	g.heartbeatCancel = nil
+	g.heartbeatMu.Unlock()
+	if heartbeatCancel != nil {
 		g.config.Logger.DebugContext(ctx, "closing heartbeat goroutine")
-		g.heartbeatCancel()
+		heartbeatCancel()
 	}
 
 	g.connMu.Lock()
@@ -332,10 +429,9 @@ func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message strin
 
 		// clear resume data as we closed gracefully
 		if code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway {
-			g.config.SessionID = nil
-			g.config.ResumeURL = nil
-			g.config.LastSequenceReceived = nil
+			g.clearSession()
 		}
+		g.storeSession(ctx)
 	}
 	g.statusMu.Lock()
 	g.status = StatusDisconnected
@@ -379,6 +475,8 @@ func (g *gatewayImpl) sendInternal(ctx context.Context, commandType RateLimiterC
 }
 
 func (g *gatewayImpl) Latency() time.Duration {
+	g.heartbeatMu.Lock()
+	defer g.heartbeatMu.Unlock()
 	return g.lastHeartbeatReceived.Sub(g.lastHeartbeatSent)
 }
 
@@ -386,24 +484,32 @@ func (g *gatewayImpl) Presence() *MessageDataPresenceUpdate {
 	return g.config.Presence
 }
 
-func (g *gatewayImpl) doReconnect(ctx context.Context) error {
-	var (
-		try              int
-		backoffIncrement int
-	)
+// doReconnect opens the Gateway, retrying as configured by the BackoffPolicy.
+// If reconnecting is true, an EventReconnectAttempt is emitted before every attempt, otherwise only before retries.
+func (g *gatewayImpl) doReconnect(ctx context.Context, reconnecting bool) error {
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		if g.config.BackoffPolicy.Exhausted(attempt) {
+			return fmt.Errorf("%w: %w", ErrMaxReconnectAttempts, lastErr)
+		}
 
-	for {
-		// Exponentially backoff up to a limit of 10s
-		delay := time.Duration(1<<backoffIncrement) * time.Second
-		if delay > maximumConnectDelay {
-			delay = maximumConnectDelay
-		} else {
-			backoffIncrement++
+		delay := g.config.BackoffPolicy.Delay(attempt)
+		if reconnecting || attempt > 0 {
+			sequence := 0
+			if lastSequence := g.LastSequenceReceived(); lastSequence != nil {
+				sequence = *lastSequence
+			}
+			g.eventHandlerFunc(g, EventTypeReconnectAttempt, sequence, EventReconnectAttempt{
+				Attempt: attempt + 1,
+				Delay:   delay,
+				Err:     lastErr,
+			})
 		}
 
 		timer := time.NewTimer(delay)
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return ctx.Err()
 		case <-timer.C:
 		}
@@ -417,7 +523,7 @@ func (g *gatewayImpl) doReconnect(ctx context.Context) error {
 		var closeError *websocket.CloseError
 		if errors.As(err, &closeError) {
 			closeCode := CloseEventCodeByCode(closeError.Code)
-			if !closeCode.Reconnect {
+			if !g.config.BackoffPolicy.Reconnect(closeError.Code, closeCode.Reconnect) {
 				return err
 			}
 		}
@@ -425,17 +531,17 @@ func (g *gatewayImpl) doReconnect(ctx context.Context) error {
 			return err
 		}
 
-		g.config.Logger.ErrorContext(ctx, "failed to reconnect gateway", slog.Any("err", err), slog.Int("try", try), slog.Duration("delay", delay))
+		g.config.Logger.ErrorContext(ctx, "failed to reconnect gateway", slog.Any("err", err), slog.Int("try", attempt), slog.Duration("delay", delay))
 		g.statusMu.Lock()
 		g.status = StatusDisconnected
 		g.statusMu.Unlock()
 
-		try++
+		lastErr = err
 	}
 }
 
 func (g *gatewayImpl) reconnect() {
-	if err := g.doReconnect(context.Background()); err != nil {
+	if err := g.doReconnect(context.Background(), true); err != nil {
 		g.config.Logger.Error("failed to reopen gateway", slog.Any("err", err))
 
 		if g.config.CloseHandler != nil {
@@ -444,24 +550,40 @@ func (g *gatewayImpl) reconnect() {
 	}
 }
 
-func (g *gatewayImpl) heartbeat() {
-	defer g.config.Logger.Debug("exiting heartbeat goroutine")
-
+// startHeartbeat stops the previous heartbeat goroutine and starts a new one with the interval.
+// The cancel func is set before the goroutine starts, so CloseWithCode always stops it.
+func (g *gatewayImpl) startHeartbeat(interval time.Duration) {
 	ctx, cancel := context.WithCancel(context.Background())
-	g.heartbeatCancel = cancel
 
+	g.heartbeatMu.Lock()
+	if g.heartbeatCancel != nil {
+		g.heartbeatCancel()
+	}
+	g.heartbeatCancel = cancel
+	g.heartbeatInterval = interval
 	g.lastHeartbeatReceived = time.Now()
+	g.heartbeatMu.Unlock()
+
+	go g.heartbeat(ctx, interval)
+}
+
+func (g *gatewayImpl) heartbeat(ctx context.Context, interval time.Duration) {
+	defer g.config.Logger.Debug("exiting heartbeat goroutine")
 
 	// Send heartbeats periodically every `heartbeat_interval`
-	heartbeatTicker := time.NewTicker(g.heartbeatInterval)
+	heartbeatTicker := time.NewTicker(interval)
+	defer heartbeatTicker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
 
 		case <-heartbeatTicker.C:
-			if g.lastHeartbeatSent.After(g.lastHeartbeatReceived) {
-				lastHeartbeatAgo := time.Since(g.lastHeartbeatReceived)
+			g.heartbeatMu.Lock()
+			lastHeartbeatSent, lastHeartbeatReceived := g.lastHeartbeatSent, g.lastHeartbeatReceived
+			g.heartbeatMu.Unlock()
+			if lastHeartbeatSent.After(lastHeartbeatReceived) {
+				lastHeartbeatAgo := time.Since(lastHeartbeatReceived)
 				g.config.Logger.Warn("ACK of last heartbeat not received, connection went zombie", slog.Duration("last_heartbeat_ago", lastHeartbeatAgo))
 				closeCtx, closeCancel := context.WithTimeout(context.Background(), 5*time.Second)
 				g.CloseWithCode(closeCtx, websocket.CloseServiceRestart, "heartbeat ACK not received")
@@ -479,11 +601,15 @@ func (g *gatewayImpl) sendHeartbeat() {
 	g.config.Logger.Debug("sending heartbeat")
 
 	sequence := 0
-	if g.config.LastSequenceReceived != nil {
-		sequence = *g.config.LastSequenceReceived
+	if lastSequence := g.LastSequenceReceived(); lastSequence != nil {
+		sequence = *lastSequence
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
+	g.heartbeatMu.Lock()
+	interval := g.heartbeatInterval
+	g.heartbeatMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), interval)
 	defer cancel()
 	if err := g.sendInternal(ctx, InternalCommandType, OpcodeHeartbeat, MessageDataHeartbeat(sequence)); err != nil {
 		if errors.Is(err, discord.ErrShardNotConnected) || errors.Is(err, syscall.EPIPE) {
@@ -496,7 +622,10 @@ func (g *gatewayImpl) sendHeartbeat() {
 		go g.reconnect()
 		return
 	}
+	g.heartbeatMu.Lock()
 	g.lastHeartbeatSent = time.Now()
+	g.heartbeatMu.Unlock()
+	g.storeSession(ctx)
 }
 
 func (g *gatewayImpl) identify() error {
@@ -535,10 +664,11 @@ func (g *gatewayImpl) resume() error {
 	g.statusMu.Lock()
 	g.status = StatusResuming
 	g.statusMu.Unlock()
+	sessionID, sequence, _ := g.session()
 	resume := MessageDataResume{
 		Token:     g.token,
-		SessionID: *g.config.SessionID,
-		Seq:       *g.config.LastSequenceReceived,
+		SessionID: *sessionID,
+		Seq:       *sequence,
 	}
 	g.config.Logger.Debug("sending Resume command")
 
@@ -579,12 +709,10 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 			var closeError *websocket.CloseError
 			if errors.As(err, &closeError) {
 				closeCode := CloseEventCodeByCode(closeError.Code)
-				reconnect = closeCode.Reconnect
+				reconnect = g.config.BackoffPolicy.Reconnect(closeError.Code, closeCode.Reconnect)
 
 				if closeCode == CloseEventCodeInvalidSeq {
-					g.config.LastSequenceReceived = nil
-					g.config.SessionID = nil
-					g.config.ResumeURL = nil
+					g.clearSession()
 				}
 				msg := "gateway close received"
 				args := []any{
@@ -620,13 +748,17 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 			// No message (probably parsing error), just continue as the transport already logged it
 			continue
 		}
+		if g.config.Recorder != nil {
+			if err = g.config.Recorder.Record(g.config.ShardID, *message); err != nil {
+				g.config.Logger.Error("failed to record gateway message", slog.Any("err", err))
+			}
+		}
 
 		switch message.Op {
 		case OpcodeHello:
-			g.heartbeatInterval = time.Duration(message.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond
-			go g.heartbeat()
+			g.startHeartbeat(time.Duration(message.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond)
 
-			if g.config.LastSequenceReceived == nil || g.config.SessionID == nil {
+			if sessionID, sequence, _ := g.session(); sequence == nil || sessionID == nil {
 				err = g.identify()
 			} else {
 				err = g.resume()
@@ -638,7 +770,9 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 
 		case OpcodeDispatch:
 			// set last sequence received
+			g.sessionMu.Lock()
 			g.config.LastSequenceReceived = &message.S
+			g.sessionMu.Unlock()
 
 			eventData, ok := message.D.(EventData)
 			if !ok && message.D != nil {
@@ -647,15 +781,19 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 			}
 
 			if readyEvent, ok := eventData.(EventReady); ok {
+				g.sessionMu.Lock()
 				g.config.SessionID = &readyEvent.SessionID
 				g.config.ResumeURL = &readyEvent.ResumeGatewayURL
-				g.config.Logger.Debug("successfully identified", slog.String("session_id", *g.config.SessionID))
+				g.sessionMu.Unlock()
+				g.config.Logger.Debug("successfully identified", slog.String("session_id", readyEvent.SessionID))
+				g.storeSession(context.Background())
 				g.statusMu.Lock()
 				g.status = StatusReady
 				g.statusMu.Unlock()
 				ready(nil)
 			} else if _, ok = eventData.(EventResumed); ok {
-				g.config.Logger.Debug("successfully resumed", slog.String("session_id", *g.config.SessionID))
+				g.config.Logger.Debug("successfully resumed", slog.String("session_id", *g.SessionID()))
+				g.storeSession(context.Background())
 				g.statusMu.Lock()
 				g.status = StatusReady
 				g.statusMu.Unlock()
@@ -707,9 +845,7 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 				code = websocket.CloseServiceRestart
 			} else {
 				// clear resume info
-				g.config.SessionID = nil
-				g.config.LastSequenceReceived = nil
-				g.config.ResumeURL = nil
+				g.clearSession()
 			}
 
 			g.config.Logger.Warn("received invalid session", slog.Bool("can_resume", bool(canResume)))
@@ -730,9 +866,11 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 
 		case OpcodeHeartbeatACK:
 			newHeartbeat := time.Now()
+			g.heartbeatMu.Lock()
 			g.lastHeartbeatReceived = newHeartbeat
+			g.heartbeatMu.Unlock()
 			g.eventHandlerFunc(g, EventTypeHeartbeatAck, message.S, EventHeartbeatAck{
-				LastHeartbeat: g.lastHeartbeatReceived,
+				LastHeartbeat: newHeartbeat,
 				NewHeartbeat:  newHeartbeat,
 			})
 
@@ -19,6 +19,7 @@ func defaultConfig() config {
 		AutoReconnect:       true,
 		EnableResumeURL:     true,
 		IdentifyRateLimiter: NewNoopIdentifyRateLimiter(),
+		BackoffPolicy:       DefaultBackoffPolicy(),
 	}
 }
 
@@ -46,8 +47,14 @@ type config struct {
 	ResumeURL *string
 	// LastSequenceReceived is the last sequence received by the Gateway. Defaults to nil (no resume).
 	LastSequenceReceived *int
+	// SessionStore persists the session of the Gateway to resume it after a restart. Defaults to nil (no persistence).
+	SessionStore SessionStore
+	// Recorder records all received messages. Defaults to nil (no recording).
+	Recorder Recorder
 	// AutoReconnect is whether the Gateway should automatically reconnect or call the CloseHandlerFunc. Defaults to true.
 	AutoReconnect bool
+	// BackoffPolicy decides how long to wait between (re)connect attempts. Defaults to DefaultBackoffPolicy().
+	BackoffPolicy BackoffPolicy
 	// EnableRawEvents is whether the Gateway should emit EventRaw. Defaults to false.
 	EnableRawEvents bool
 	// EnableResumeURL is whether the Gateway should enable the resumeURL. Defaults to true.
@@ -171,6 +178,22 @@ func WithSequence(sequence int) ConfigOpt {
 	}
 }
 
+// WithSessionStore sets the SessionStore used to persist the session of the Gateway.
+// If no session is set via WithSessionID & WithSequence, the Gateway resumes the stored session on Open.
+func WithSessionStore(sessionStore SessionStore) ConfigOpt {
+	return func(config *config) {
+		config.SessionStore = sessionStore
+	}
+}
+
+// WithRecorder sets the Recorder which records all messages received by the Gateway.
+// The Recorder is not closed by the Gateway.
+func WithRecorder(recorder Recorder) ConfigOpt {
+	return func(config *config) {
+		config.Recorder = recorder
+	}
+}
+
 // WithAutoReconnect sets whether the Gateway should automatically reconnect to Discord.
 func WithAutoReconnect(autoReconnect bool) ConfigOpt {
 	return func(config *config) {
@@ -178,6 +201,14 @@ func WithAutoReconnect(autoReconnect bool) ConfigOpt {
 	}
 }
 
+// WithBackoffPolicy sets the BackoffPolicy which decides how long the Gateway waits between (re)connect attempts,
+// when it gives up and after which close codes it reconnects. A zero Base or Max is taken from DefaultBackoffPolicy.
+func WithBackoffPolicy(policy BackoffPolicy) ConfigOpt {
+	return func(config *config) {
+		config.BackoffPolicy = policy.WithDefaults(DefaultBackoffPolicy())
+	}
+}
+
 // WithEnableRawEvents enables/disables the EventTypeRaw.
 func WithEnableRawEvents(enableRawEventEvents bool) ConfigOpt {
 	return func(config *config) {
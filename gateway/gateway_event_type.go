@@ -8,6 +8,7 @@ const (
 	// EventTypeRaw is not a real event type, but is used to pass raw payloads to the bot.EventManager
 	EventTypeRaw                                 EventType = "__RAW__"
 	EventTypeHeartbeatAck                        EventType = "__HEARTBEAT_ACK__"
+	EventTypeReconnectAttempt                    EventType = "__RECONNECT_ATTEMPT__"
 	EventTypeReady                               EventType = "READY"
 	EventTypeResumed                             EventType = "RESUMED"
 	EventTypeRateLimited                         EventType = "RATE_LIMITED"
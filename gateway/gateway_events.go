@@ -1071,6 +1071,19 @@ type EventHeartbeatAck struct {
 func (EventHeartbeatAck) messageData() {}
 func (EventHeartbeatAck) eventData()   {}
 
+// EventReconnectAttempt is not sent by Discord, the Gateway emits it before each reconnect attempt.
+type EventReconnectAttempt struct {
+	// Attempt is the number of the attempt, starting at 1.
+	Attempt int
+	// Delay is how long the Gateway waits before the attempt.
+	Delay time.Duration
+	// Err is the error of the previous failed attempt or nil.
+	Err error
+}
+
+func (EventReconnectAttempt) messageData() {}
+func (EventReconnectAttempt) eventData()   {}
+
 type EventEntitlementCreate struct {
 	discord.Entitlement
 }
@@ -111,6 +111,7 @@ func (t *baseTransport) WriteMessage(message Message) error {
 	if t.logger.Enabled(context.Background(), slog.LevelDebug) {
 		t.logger.Debug("sending gateway message", slog.String("data", string(data)))
 	}
+
 	return t.conn.WriteMessage(websocket.TextMessage, data)
 }
 
@@ -170,6 +171,7 @@ func newZstdStreamTransport(conn *websocket.Conn, logger *slog.Logger) *zstdStre
 func (t *zstdStreamTransport) ReceiveMessage() (*Message, error) {
 	mt, data, err := t.conn.ReadMessage()
 	if err != nil {
+		t.release()
 		return nil, err
 	}
 
@@ -190,13 +192,18 @@ func (t *zstdStreamTransport) ReceiveMessage() (*Message, error) {
 	return t.parseMessage(t.inflator)
 }
 
-func (t *zstdStreamTransport) Close() error {
-	connClose := t.conn.Close()
+// release releases the inflator. It is called by ReceiveMessage once the connection is closed,
+// as the inflator must not be used concurrently with ReceiveMessage.
+func (t *zstdStreamTransport) release() {
 	t.buffer.Reset()
 	if t.inflator != nil {
 		t.inflator.Close()
+		t.inflator = nil
 	}
-	return connClose
+}
+
+func (t *zstdStreamTransport) Close() error {
+	return t.conn.Close()
 }
 
 // zlibStreamTransport implements zlib-stream compression.
@@ -230,6 +237,7 @@ func (t *zlibStreamTransport) ReceiveMessage() (*Message, error) {
 	for {
 		mt, data, err := t.conn.ReadMessage()
 		if err != nil {
+			t.release()
 			return nil, err
 		}
 		if mt != websocket.BinaryMessage {
@@ -256,13 +264,18 @@ func (t *zlibStreamTransport) ReceiveMessage() (*Message, error) {
 	return t.parseMessage(t.inflator)
 }
 
-func (t *zlibStreamTransport) Close() error {
-	connClose := t.conn.Close()
+// release releases the inflator. It is called by ReceiveMessage once the connection is closed,
+// as the inflator must not be used concurrently with ReceiveMessage.
+func (t *zlibStreamTransport) release() {
 	t.buffer.Reset()
 	if t.inflator != nil {
 		_ = t.inflator.Close()
+		t.inflator = nil
 	}
-	return connClose
+}
+
+func (t *zlibStreamTransport) Close() error {
+	return t.conn.Close()
 }
 
 // zlibPayloadTransport implements both no compression and payload zlib compression.
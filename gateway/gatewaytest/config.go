@@ -0,0 +1,94 @@
+package gatewaytest
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func defaultConfig() config {
+	return config{
+		Logger: slog.Default(),
+		BotUser: discord.User{
+			ID:       snowflake.New(time.Now()),
+			Username: "gatewaytest",
+			Bot:      true,
+		},
+		HeartbeatInterval: 41250 * time.Millisecond,
+	}
+}
+
+type config struct {
+	Logger            *slog.Logger
+	Token             string
+	BotUser           discord.User
+	Guilds            []snowflake.ID
+	HeartbeatInterval time.Duration
+	ConnectHandler    func(conn *Conn)
+	MessageHandler    func(conn *Conn, message gateway.Message) bool
+}
+
+// ConfigOpt can be used to supply optional parameters to NewServer
+type ConfigOpt func(config *config)
+
+func (c *config) apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "gatewaytest"))
+}
+
+// WithLogger applies a custom logger to the Server
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *config) {
+		config.Logger = logger
+	}
+}
+
+// WithToken makes the Server close connections which identify or resume with another token with gateway.CloseEventCodeAuthenticationFailed.
+// By default, any token is accepted.
+func WithToken(token string) ConfigOpt {
+	return func(config *config) {
+		config.Token = token
+	}
+}
+
+// WithBotUser sets the user sent in the gateway.EventReady. Its ID is also used as the application ID.
+func WithBotUser(user discord.User) ConfigOpt {
+	return func(config *config) {
+		config.BotUser = user
+	}
+}
+
+// WithGuilds sets the IDs of the unavailable guilds sent in the gateway.EventReady.
+func WithGuilds(guildIDs ...snowflake.ID) ConfigOpt {
+	return func(config *config) {
+		config.Guilds = guildIDs
+	}
+}
+
+// WithHeartbeatInterval sets the heartbeat interval sent in the HELLO. Defaults to 41.25s.
+func WithHeartbeatInterval(interval time.Duration) ConfigOpt {
+	return func(config *config) {
+		config.HeartbeatInterval = interval
+	}
+}
+
+// WithConnectHandler sets a function which is called for each new connection after the HELLO was sent.
+func WithConnectHandler(handler func(conn *Conn)) ConfigOpt {
+	return func(config *config) {
+		config.ConnectHandler = handler
+	}
+}
+
+// WithMessageHandler sets a function which is called for each message received from a client before the Server handles it.
+// If it returns true, the Server doesn't handle the message itself, e.g. to not answer an IDENTIFY.
+func WithMessageHandler(handler func(conn *Conn, message gateway.Message) bool) ConfigOpt {
+	return func(config *config) {
+		config.MessageHandler = handler
+	}
+}
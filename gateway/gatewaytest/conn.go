@@ -0,0 +1,293 @@
+package gatewaytest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/gorilla/websocket"
+	"github.com/klauspost/compress/zlib"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// ErrNotIdentified is returned by Conn.Dispatch if the connection has no session yet.
+var ErrNotIdentified = errors.New("connection is not identified")
+
+// Conn is a client connection to the Server. Its methods can be used to script the behavior of the gateway.
+type Conn struct {
+	server      *Server
+	ws          *websocket.Conn
+	compression gateway.CompressionType
+
+	writeMu            sync.Mutex
+	payloadCompression bool
+	buf                bytes.Buffer
+	zlibWriter         *zlib.Writer
+	zstdWriter         *zstd.Encoder
+
+	mu         sync.Mutex
+	session    *session
+	resumed    bool
+	disableACK bool
+	closed     bool
+}
+
+func newConn(server *Server, ws *websocket.Conn, compression gateway.CompressionType) *Conn {
+	return &Conn{
+		server:      server,
+		ws:          ws,
+		compression: compression,
+	}
+}
+
+// SessionID returns the ID of the session of the connection or an empty string if it didn't identify or resume yet.
+func (c *Conn) SessionID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session == nil {
+		return ""
+	}
+	return c.session.id
+}
+
+// ShardID returns the shard ID the session was identified with.
+func (c *Conn) ShardID() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session == nil {
+		return 0
+	}
+	return c.session.shardID
+}
+
+// Resumed returns whether the connection resumed an existing session.
+func (c *Conn) Resumed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resumed
+}
+
+// Compression returns the compression requested by the client.
+func (c *Conn) Compression() gateway.CompressionType {
+	return c.compression
+}
+
+// SetHeartbeatACK sets whether heartbeats are acknowledged, which is enabled by default.
+// Disabling it lets the client detect a zombie connection.
+func (c *Conn) SetHeartbeatACK(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.disableACK = !enabled
+}
+
+// Dispatch sends the event with the next sequence of the session.
+// The event is stored in the session, so it is replayed if the client resumes after the connection failed.
+func (c *Conn) Dispatch(eventType gateway.EventType, data any) error {
+	c.mu.Lock()
+	sess := c.session
+	c.mu.Unlock()
+	if sess == nil {
+		return ErrNotIdentified
+	}
+
+	d, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	sess.mu.Lock()
+	sess.sequence++
+	sequence := sess.sequence
+	sess.dispatches = append(sess.dispatches, dispatch{sequence: sequence, eventType: eventType, data: d})
+	sess.mu.Unlock()
+
+	return c.write(gateway.OpcodeDispatch, sequence, eventType, d)
+}
+
+// Send sends a message with the opcode & data without sequence.
+func (c *Conn) Send(op gateway.Opcode, data any) error {
+	return c.send(op, data)
+}
+
+// Reconnect sends a RECONNECT, asking the client to reconnect & resume.
+func (c *Conn) Reconnect() error {
+	return c.send(gateway.OpcodeReconnect, nil)
+}
+
+// InvalidateSession sends an INVALID_SESSION. If resumable is false, the session is deleted so the client has to identify again.
+func (c *Conn) InvalidateSession(resumable bool) error {
+	if !resumable {
+		if sessionID := c.SessionID(); sessionID != "" {
+			c.server.deleteSession(sessionID)
+		}
+	}
+	return c.send(gateway.OpcodeInvalidSession, gateway.MessageDataInvalidSession(resumable))
+}
+
+// Close sends a close frame with the code & text and closes the connection.
+// See gateway.CloseEventCode for the close codes Discord uses.
+func (c *Conn) Close(code int, text string) error {
+	c.writeMu.Lock()
+	err := c.ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, text))
+	c.writeMu.Unlock()
+	if closeErr := c.Kill(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Kill closes the connection without close frame, like a network failure.
+func (c *Conn) Kill() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	return c.ws.Close()
+}
+
+func (c *Conn) isOpen() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.closed
+}
+
+func (c *Conn) heartbeatACK() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.disableACK
+}
+
+func (c *Conn) setSession(sess *session, resumed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.session = sess
+	c.resumed = resumed
+}
+
+func (c *Conn) setPayloadCompression(enabled bool) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.payloadCompression = enabled
+}
+
+// replay sends all dispatches of the session after the sequence.
+func (c *Conn) replay(sequence int) error {
+	c.mu.Lock()
+	sess := c.session
+	c.mu.Unlock()
+
+	sess.mu.Lock()
+	var dispatches []dispatch
+	for _, d := range sess.dispatches {
+		if d.sequence > sequence {
+			dispatches = append(dispatches, d)
+		}
+	}
+	sess.mu.Unlock()
+
+	for _, d := range dispatches {
+		if err := c.write(gateway.OpcodeDispatch, d.sequence, d.eventType, d.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Conn) send(op gateway.Opcode, data any) error {
+	d, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return c.write(op, 0, "", d)
+}
+
+// write encodes the message, compresses it as requested by the client and sends it.
+func (c *Conn) write(op gateway.Opcode, sequence int, eventType gateway.EventType, d json.RawMessage) error {
+	message := struct {
+		Op gateway.Opcode     `json:"op"`
+		S  *int               `json:"s"`
+		T  *gateway.EventType `json:"t"`
+		D  json.RawMessage    `json:"d"`
+	}{Op: op, D: d}
+	if op == gateway.OpcodeDispatch {
+		message.S = &sequence
+		message.T = &eventType
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if data, err = c.compress(data); err != nil {
+		return err
+	}
+	messageType := websocket.TextMessage
+	if c.compression.IsStreamCompression() || c.payloadCompression {
+		messageType = websocket.BinaryMessage
+	}
+	return c.ws.WriteMessage(messageType, data)
+}
+
+// compress compresses the data with the stream or payload compression. It must be called with the write lock held.
+func (c *Conn) compress(data []byte) ([]byte, error) {
+	var err error
+	switch {
+	case c.compression == gateway.CompressionZlibStream:
+		if c.zlibWriter == nil {
+			c.zlibWriter = zlib.NewWriter(&c.buf)
+		}
+		if _, err = c.zlibWriter.Write(data); err != nil {
+			return nil, err
+		}
+		// flushing ends the message with the zlib suffix the client waits for
+		err = c.zlibWriter.Flush()
+
+	case c.compression == gateway.CompressionZstdStream:
+		if c.zstdWriter == nil {
+			if c.zstdWriter, err = zstd.NewWriter(&c.buf, zstd.WithEncoderConcurrency(1)); err != nil {
+				return nil, err
+			}
+		}
+		if _, err = c.zstdWriter.Write(data); err != nil {
+			return nil, err
+		}
+		err = c.zstdWriter.Flush()
+
+	case c.payloadCompression:
+		w := zlib.NewWriter(&c.buf)
+		if _, err = w.Write(data); err != nil {
+			return nil, err
+		}
+		err = w.Close()
+
+	default:
+		return data, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress message: %w", err)
+	}
+
+	compressed := bytes.Clone(c.buf.Bytes())
+	c.buf.Reset()
+	return compressed, nil
+}
+
+// read reads the next message of the client.
+func (c *Conn) read() (*gateway.Message, error) {
+	_, data, err := c.ws.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	var message gateway.Message
+	if err = json.Unmarshal(data, &message); err != nil {
+		_ = c.Close(gateway.CloseEventCodeDecodeError.Code, "Error while decoding payload.")
+		return nil, err
+	}
+	return &message, nil
+}
@@ -0,0 +1,15 @@
+// Package gatewaytest provides a local fake of the Discord gateway for tests.
+//
+// The Server speaks the gateway protocol: it sends HELLO, answers heartbeats, creates sessions on IDENTIFY,
+// replays missed dispatches on RESUME and supports zlib-stream, zstd-stream & payload compression.
+// Each Conn can be scripted to dispatch events, request reconnects, invalidate sessions, stop acknowledging heartbeats or close with arbitrary close codes:
+//
+//	fake := gatewaytest.NewServer()
+//	defer fake.Close()
+//
+//	g := gateway.New("token", eventHandlerFunc, gateway.WithURL(fake.GatewayURL()))
+//	_ = g.Open(ctx)
+//
+//	conn, _ := fake.NextConn(ctx)
+//	_ = conn.Close(4000, "unknown error")
+package gatewaytest
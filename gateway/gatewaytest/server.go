@@ -0,0 +1,294 @@
+package gatewaytest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// NewServer starts a new Server. Close it when done.
+func NewServer(opts ...ConfigOpt) *Server {
+	cfg := defaultConfig()
+	cfg.apply(opts)
+
+	s := &Server{
+		config:      cfg,
+		conns:       map[*Conn]struct{}{},
+		sessions:    map[string]*session{},
+		readyNotify: make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Server is a local fake of the Discord gateway.
+// Its GatewayURL can be used with gateway.WithURL.
+type Server struct {
+	*httptest.Server
+
+	config   config
+	upgrader websocket.Upgrader
+
+	mu          sync.Mutex
+	conns       map[*Conn]struct{}
+	sessions    map[string]*session
+	identifies  int
+	resumes     int
+	readyConns  []*Conn
+	nextReady   int
+	readyNotify chan struct{}
+}
+
+// session is a gateway session which survives connections & stores all dispatches to replay them on resume.
+type session struct {
+	mu         sync.Mutex
+	id         string
+	shardID    int
+	shardCount int
+	sequence   int
+	dispatches []dispatch
+}
+
+type dispatch struct {
+	sequence  int
+	eventType gateway.EventType
+	data      []byte
+}
+
+// GatewayURL returns the websocket URL of the Server.
+func (s *Server) GatewayURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// Identifies returns how many sessions were created by IDENTIFY.
+func (s *Server) Identifies() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.identifies
+}
+
+// Resumes returns how many sessions were resumed by RESUME.
+func (s *Server) Resumes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resumes
+}
+
+// NextConn returns the next connection which became ready by identifying or resuming, in the order they became ready.
+// It blocks until a connection becomes ready or the context is done.
+func (s *Server) NextConn(ctx context.Context) (*Conn, error) {
+	for {
+		s.mu.Lock()
+		if s.nextReady < len(s.readyConns) {
+			conn := s.readyConns[s.nextReady]
+			s.nextReady++
+			s.mu.Unlock()
+			return conn, nil
+		}
+		notify := s.readyNotify
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// Close closes all connections without close frame and shuts down the Server.
+func (s *Server) Close() {
+	s.mu.Lock()
+	conns := make([]*Conn, 0, len(s.conns))
+	for conn := range s.conns {
+		conns = append(conns, conn)
+	}
+	s.mu.Unlock()
+
+	for _, conn := range conns {
+		_ = conn.Kill()
+	}
+	s.Server.Close()
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.config.Logger.Error("failed to upgrade connection", slog.Any("err", err))
+		return
+	}
+
+	conn := newConn(s, ws, gateway.CompressionType(r.URL.Query().Get("compress")))
+
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = ws.Close()
+	}()
+
+	if err = conn.send(gateway.OpcodeHello, gateway.MessageDataHello{HeartbeatInterval: int(s.config.HeartbeatInterval.Milliseconds())}); err != nil {
+		return
+	}
+	if s.config.ConnectHandler != nil {
+		s.config.ConnectHandler(conn)
+	}
+
+	for {
+		message, err := conn.read()
+		if err != nil {
+			// like Discord, a normal closure by the client invalidates the session
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				if sessionID := conn.SessionID(); sessionID != "" {
+					s.deleteSession(sessionID)
+				}
+			}
+			if conn.isOpen() {
+				s.config.Logger.Debug("failed to read message", slog.Any("err", err))
+			}
+			return
+		}
+		if s.config.MessageHandler != nil && s.config.MessageHandler(conn, *message) {
+			continue
+		}
+		s.handleMessage(conn, *message)
+	}
+}
+
+func (s *Server) handleMessage(conn *Conn, message gateway.Message) {
+	switch message.Op {
+	case gateway.OpcodeHeartbeat:
+		if conn.heartbeatACK() {
+			_ = conn.send(gateway.OpcodeHeartbeatACK, nil)
+		}
+
+	case gateway.OpcodeIdentify:
+		s.identify(conn, message.D.(gateway.MessageDataIdentify))
+
+	case gateway.OpcodeResume:
+		s.resume(conn, message.D.(gateway.MessageDataResume))
+
+	default:
+		if conn.SessionID() == "" {
+			_ = conn.Close(gateway.CloseEventCodeNotAuthenticated.Code, "Not authenticated.")
+		}
+	}
+}
+
+func (s *Server) authenticate(conn *Conn, token string) bool {
+	if conn.SessionID() != "" {
+		_ = conn.Close(gateway.CloseEventCodeAlreadyAuthenticated.Code, "Already authenticated.")
+		return false
+	}
+	if s.config.Token != "" && token != s.config.Token {
+		_ = conn.Close(gateway.CloseEventCodeAuthenticationFailed.Code, "Authentication failed.")
+		return false
+	}
+	return true
+}
+
+func (s *Server) identify(conn *Conn, identify gateway.MessageDataIdentify) {
+	if !s.authenticate(conn, identify.Token) {
+		return
+	}
+	conn.setPayloadCompression(identify.Compress)
+
+	sess := &session{
+		id:         newSessionID(),
+		shardCount: 1,
+	}
+	if identify.Shard != nil {
+		sess.shardID = identify.Shard[0]
+		sess.shardCount = identify.Shard[1]
+	}
+
+	s.mu.Lock()
+	s.sessions[sess.id] = sess
+	s.identifies++
+	s.mu.Unlock()
+	conn.setSession(sess, false)
+
+	guilds := make([]discord.UnavailableGuild, len(s.config.Guilds))
+	for i, guildID := range s.config.Guilds {
+		guilds[i] = discord.UnavailableGuild{ID: guildID, Unavailable: true}
+	}
+	if err := conn.Dispatch(gateway.EventTypeReady, gateway.EventReady{
+		Version:          gateway.Version,
+		User:             discord.OAuth2User{User: s.config.BotUser},
+		Guilds:           guilds,
+		SessionID:        sess.id,
+		ResumeGatewayURL: s.GatewayURL(),
+		Shard:            [2]int{sess.shardID, sess.shardCount},
+		Application:      discord.PartialApplication{ID: s.config.BotUser.ID},
+	}); err != nil {
+		return
+	}
+	s.ready(conn)
+}
+
+func (s *Server) resume(conn *Conn, resume gateway.MessageDataResume) {
+	if !s.authenticate(conn, resume.Token) {
+		return
+	}
+
+	s.mu.Lock()
+	sess, ok := s.sessions[resume.SessionID]
+	s.mu.Unlock()
+	if !ok {
+		_ = conn.send(gateway.OpcodeInvalidSession, gateway.MessageDataInvalidSession(false))
+		return
+	}
+	sess.mu.Lock()
+	sequence := sess.sequence
+	sess.mu.Unlock()
+	if resume.Seq > sequence {
+		_ = conn.Close(gateway.CloseEventCodeInvalidSeq.Code, "Invalid seq.")
+		return
+	}
+
+	conn.setSession(sess, true)
+	if err := conn.replay(resume.Seq); err != nil {
+		return
+	}
+	if err := conn.Dispatch(gateway.EventTypeResumed, gateway.EventResumed{}); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.resumes++
+	s.mu.Unlock()
+	s.ready(conn)
+}
+
+func (s *Server) ready(conn *Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readyConns = append(s.readyConns, conn)
+	close(s.readyNotify)
+	s.readyNotify = make(chan struct{})
+}
+
+func (s *Server) deleteSession(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,271 @@
+package gatewaytest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func newTestGateway(t *testing.T, fake *Server, opts ...gateway.ConfigOpt) (gateway.Gateway, <-chan gateway.EventType) {
+	t.Helper()
+	events := make(chan gateway.EventType, 100)
+	g := gateway.New("token", func(_ gateway.Gateway, eventType gateway.EventType, _ int, _ gateway.EventData) {
+		events <- eventType
+	}, append([]gateway.ConfigOpt{
+		gateway.WithURL(fake.GatewayURL()),
+		gateway.WithLogger(discardLogger),
+		gateway.WithBackoffPolicy(gateway.BackoffPolicy{Base: 10 * time.Millisecond, Max: 100 * time.Millisecond}),
+	}, opts...)...)
+	t.Cleanup(func() {
+		g.Close(context.Background())
+	})
+	return g, events
+}
+
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func waitForEvent(t *testing.T, events <-chan gateway.EventType, eventType gateway.EventType) {
+	t.Helper()
+	timer := time.NewTimer(10 * time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case e := <-events:
+			if e == eventType {
+				return
+			}
+		case <-timer.C:
+			t.Fatalf("timed out waiting for %s", eventType)
+		}
+	}
+}
+
+func TestServer_Identify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		compression gateway.CompressionType
+	}{
+		{name: "none", compression: gateway.CompressionNone},
+		{name: "zlib-payload", compression: gateway.CompressionZlibPayload},
+		{name: "zlib-stream", compression: gateway.CompressionZlibStream},
+		{name: "zstd-stream", compression: gateway.CompressionZstdStream},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testContext(t)
+			fake := NewServer(WithLogger(discardLogger))
+			t.Cleanup(fake.Close)
+
+			g, events := newTestGateway(t, fake, gateway.WithCompression(tt.compression))
+			if err := g.Open(ctx); err != nil {
+				t.Fatalf("failed to open gateway: %v", err)
+			}
+			waitForEvent(t, events, gateway.EventTypeReady)
+
+			conn, err := fake.NextConn(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if conn.Compression() != tt.compression && tt.compression.IsStreamCompression() {
+				t.Errorf("expected compression %s, got %s", tt.compression, conn.Compression())
+			}
+			for range 3 {
+				if err = conn.Dispatch(gateway.EventTypeGuildDelete, discord.UnavailableGuild{ID: 123, Unavailable: true}); err != nil {
+					t.Fatalf("failed to dispatch: %v", err)
+				}
+				waitForEvent(t, events, gateway.EventTypeGuildDelete)
+			}
+			if *g.SessionID() != conn.SessionID() || *g.LastSequenceReceived() != 4 {
+				t.Errorf("unexpected session %s/%d", *g.SessionID(), *g.LastSequenceReceived())
+			}
+		})
+	}
+}
+
+func TestServer_Resume(t *testing.T) {
+	t.Parallel()
+	ctx := testContext(t)
+	fake := NewServer(WithLogger(discardLogger))
+	t.Cleanup(fake.Close)
+
+	g, events := newTestGateway(t, fake)
+	if err := g.Open(ctx); err != nil {
+		t.Fatalf("failed to open gateway: %v", err)
+	}
+	conn, err := fake.NextConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = conn.Close(gateway.CloseEventCodeUnknownError.Code, "unknown error"); err != nil {
+		t.Fatalf("failed to close connection: %v", err)
+	}
+	// dispatched while disconnected, must be replayed on resume
+	_ = conn.Dispatch(gateway.EventTypeGuildDelete, discord.UnavailableGuild{ID: 123})
+
+	conn, err = fake.NextConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !conn.Resumed() {
+		t.Error("expected connection to resume")
+	}
+	waitForEvent(t, events, gateway.EventTypeGuildDelete)
+	waitForEvent(t, events, gateway.EventTypeResumed)
+
+	if err = conn.Reconnect(); err != nil {
+		t.Fatalf("failed to send reconnect: %v", err)
+	}
+	if conn, err = fake.NextConn(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !conn.Resumed() || fake.Identifies() != 1 || fake.Resumes() != 2 {
+		t.Errorf("expected 1 identify & 2 resumes, got %d & %d", fake.Identifies(), fake.Resumes())
+	}
+}
+
+func TestServer_InvalidSession(t *testing.T) {
+	t.Parallel()
+	ctx := testContext(t)
+	fake := NewServer(WithLogger(discardLogger))
+	t.Cleanup(fake.Close)
+
+	g, _ := newTestGateway(t, fake)
+	if err := g.Open(ctx); err != nil {
+		t.Fatalf("failed to open gateway: %v", err)
+	}
+	conn, err := fake.NextConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionID := conn.SessionID()
+
+	if err = conn.InvalidateSession(false); err != nil {
+		t.Fatalf("failed to invalidate session: %v", err)
+	}
+	if conn, err = fake.NextConn(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if conn.Resumed() || conn.SessionID() == sessionID || fake.Identifies() != 2 {
+		t.Errorf("expected new session after invalid session")
+	}
+}
+
+func TestServer_HeartbeatACKTimeout(t *testing.T) {
+	t.Parallel()
+	ctx := testContext(t)
+	fake := NewServer(WithLogger(discardLogger), WithHeartbeatInterval(50*time.Millisecond))
+	t.Cleanup(fake.Close)
+
+	g, _ := newTestGateway(t, fake)
+	if err := g.Open(ctx); err != nil {
+		t.Fatalf("failed to open gateway: %v", err)
+	}
+	conn, err := fake.NextConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetHeartbeatACK(false)
+
+	if conn, err = fake.NextConn(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !conn.Resumed() {
+		t.Error("expected zombie connection to resume")
+	}
+}
+
+func TestServer_AuthenticationFailed(t *testing.T) {
+	t.Parallel()
+	ctx := testContext(t)
+	fake := NewServer(WithLogger(discardLogger), WithToken("other"))
+	t.Cleanup(fake.Close)
+
+	g, _ := newTestGateway(t, fake)
+	err := g.Open(ctx)
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != gateway.CloseEventCodeAuthenticationFailed.Code {
+		t.Fatalf("expected authentication failed close error, got %v", err)
+	}
+}
+
+func TestServer_MaxReconnectAttempts(t *testing.T) {
+	t.Parallel()
+	ctx := testContext(t)
+
+	var rejectConns atomic.Bool
+	fake := NewServer(WithLogger(discardLogger), WithConnectHandler(func(conn *Conn) {
+		if rejectConns.Load() {
+			_ = conn.Close(gateway.CloseEventCodeUnknownError.Code, "unknown error")
+		}
+	}))
+	t.Cleanup(fake.Close)
+
+	attempts := make(chan gateway.EventReconnectAttempt, 10)
+	closed := make(chan error, 1)
+	g := gateway.New("token", func(_ gateway.Gateway, _ gateway.EventType, _ int, event gateway.EventData) {
+		if attempt, ok := event.(gateway.EventReconnectAttempt); ok {
+			attempts <- attempt
+		}
+	},
+		gateway.WithURL(fake.GatewayURL()),
+		gateway.WithLogger(discardLogger),
+		gateway.WithBackoffPolicy(gateway.BackoffPolicy{Base: 10 * time.Millisecond, Jitter: 0.5, MaxAttempts: 3}),
+		gateway.WithCloseHandler(func(_ gateway.Gateway, err error, _ bool) {
+			closed <- err
+		}),
+	)
+	t.Cleanup(func() {
+		g.Close(context.Background())
+	})
+	if err := g.Open(ctx); err != nil {
+		t.Fatalf("failed to open gateway: %v", err)
+	}
+	conn, err := fake.NextConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rejectConns.Store(true)
+	_ = conn.Kill()
+
+	select {
+	case err = <-closed:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for gateway to give up")
+	}
+	if !errors.Is(err, gateway.ErrMaxReconnectAttempts) {
+		t.Errorf("expected ErrMaxReconnectAttempts, got %v", err)
+	}
+
+	close(attempts)
+	var i int
+	for attempt := range attempts {
+		i++
+		if attempt.Attempt != i || (i > 1) != (attempt.Err != nil) {
+			t.Errorf("unexpected attempt %+v", attempt)
+		}
+	}
+	if i != 3 {
+		t.Errorf("expected 3 reconnect attempts, got %d", i)
+	}
+}
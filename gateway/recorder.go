@@ -0,0 +1,130 @@
+package gateway
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+)
+
+// Record is a Message received by a Gateway as written by a Recorder.
+type Record struct {
+	Time    time.Time       `json:"time"`
+	ShardID int             `json:"shard"`
+	Op      Opcode          `json:"op"`
+	S       int             `json:"s,omitempty"`
+	T       EventType       `json:"t,omitempty"`
+	D       json.RawMessage `json:"d,omitempty"`
+}
+
+// Recorder records all Message(s) received by Gateway(s), see WithRecorder.
+// Recordings can be replayed with NewReplayer.
+type Recorder interface {
+	// Record writes the Message received by the shard.
+	Record(shardID int, message Message) error
+	// Close finishes the recording and closes the underlying writer if it is an io.Closer.
+	Close() error
+}
+
+var _ Recorder = (*recorderImpl)(nil)
+
+// NewRecorder returns a Recorder which writes gzip compressed JSON lines of Record(s) to w.
+// Every Record is flushed to w, so a recording can be read while it is still written or after the process crashed without calling Close.
+// A Recorder can be shared by multiple shards.
+func NewRecorder(w io.Writer) Recorder {
+	buf := bufio.NewWriter(w)
+	return &recorderImpl{
+		w:    w,
+		buf:  buf,
+		gzip: gzip.NewWriter(buf),
+	}
+}
+
+type recorderImpl struct {
+	w      io.Writer
+	buf    *bufio.Writer
+	gzip   *gzip.Writer
+	closed bool
+	mu     sync.Mutex
+}
+
+func (r *recorderImpl) Record(shardID int, message Message) error {
+	data, err := json.Marshal(Record{
+		Time:    time.Now(),
+		ShardID: shardID,
+		Op:      message.Op,
+		S:       message.S,
+		T:       message.T,
+		D:       message.RawD,
+	})
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return errors.New("recorder closed")
+	}
+	if _, err = r.gzip.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err = r.gzip.Flush(); err != nil {
+		return err
+	}
+	return r.buf.Flush()
+}
+
+func (r *recorderImpl) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	err := r.gzip.Close()
+	if flushErr := r.buf.Flush(); err == nil {
+		err = flushErr
+	}
+	if closer, ok := r.w.(io.Closer); ok {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// ReadRecords returns an iterator over the Record(s) of a recording written by a Recorder.
+// Recordings which were not closed are read up to the last flushed Record.
+// The iteration stops after the first error.
+func ReadRecords(r io.Reader) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			yield(Record{}, err)
+			return
+		}
+		defer gz.Close()
+
+		decoder := json.NewDecoder(gz)
+		for {
+			var record Record
+			if err = decoder.Decode(&record); err != nil {
+				// a recording which was not closed ends without the gzip footer
+				if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+					yield(Record{}, err)
+				}
+				return
+			}
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}
+}
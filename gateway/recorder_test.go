@@ -0,0 +1,107 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+)
+
+func TestRecorder_Replayer(t *testing.T) {
+	t.Parallel()
+
+	messages := []string{
+		`{"op":10,"d":{"heartbeat_interval":41250}}`,
+		`{"op":0,"s":1,"t":"READY","d":{"v":10,"user":{"id":"123","username":"bot"},"guilds":[],"session_id":"abc","resume_gateway_url":"wss://resume.discord.gg","shard":[0,1],"application":{"id":"123","flags":0}}}`,
+		`{"op":0,"s":2,"t":"GUILD_DELETE","d":{"id":"456","unavailable":true}}`,
+		`{"op":0,"s":3,"t":"SOME_NEW_EVENT","d":{}}`,
+	}
+
+	buf := new(bytes.Buffer)
+	recorder := NewRecorder(buf)
+	for i, data := range messages {
+		var message Message
+		if err := json.Unmarshal([]byte(data), &message); err != nil {
+			t.Fatalf("failed to unmarshal message: %v", err)
+		}
+		// the second shard's messages must be filtered by the replayer
+		if err := recorder.Record(0, message); err != nil {
+			t.Fatalf("failed to record message %d: %v", i, err)
+		}
+		if err := recorder.Record(1, message); err != nil {
+			t.Fatalf("failed to record message %d: %v", i, err)
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("failed to close recorder: %v", err)
+	}
+
+	var records int
+	for record, err := range ReadRecords(bytes.NewReader(buf.Bytes())) {
+		if err != nil {
+			t.Fatalf("failed to read record: %v", err)
+		}
+		if record.Time.IsZero() {
+			t.Error("expected record time to be set")
+		}
+		records++
+	}
+	if records != len(messages)*2 {
+		t.Fatalf("expected %d records, got %d", len(messages)*2, records)
+	}
+
+	var events []EventType
+	replayer := NewReplayer(bytes.NewReader(buf.Bytes()), func(gateway Gateway, eventType EventType, sequenceNumber int, event EventData) {
+		if gateway.ShardID() != 0 {
+			t.Errorf("expected shard 0, got %d", gateway.ShardID())
+		}
+		events = append(events, eventType)
+	}, WithReplaySpeed(0), WithReplayShardIDs(0))
+
+	if err := replayer.Open(context.Background()); err != nil {
+		t.Fatalf("failed to open replayer: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := replayer.Wait(ctx); err != nil {
+		t.Fatalf("failed to replay: %v", err)
+	}
+
+	if len(events) != 2 || events[0] != EventTypeReady || events[1] != EventTypeGuildDelete {
+		t.Errorf("unexpected replayed events %v", events)
+	}
+	if replayer.SessionID() == nil || *replayer.SessionID() != "abc" || *replayer.LastSequenceReceived() != 3 {
+		t.Errorf("expected session of replayed ready event")
+	}
+	if replayer.Status() != StatusDisconnected {
+		t.Errorf("expected replayer to be disconnected, got %s", replayer.Status())
+	}
+}
+
+func TestRecorder_ReadWithoutClose(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	recorder := NewRecorder(buf)
+	for i := range 2 {
+		if err := recorder.Record(0, Message{Op: OpcodeDispatch, S: i + 1, T: EventTypeGuildDelete, RawD: json.RawMessage(`{"id":"456"}`)}); err != nil {
+			t.Fatalf("failed to record message %d: %v", i, err)
+		}
+
+		var records int
+		for record, err := range ReadRecords(bytes.NewReader(buf.Bytes())) {
+			if err != nil {
+				t.Fatalf("failed to read record: %v", err)
+			}
+			if record.S != records+1 {
+				t.Errorf("expected sequence %d, got %d", records+1, record.S)
+			}
+			records++
+		}
+		if records != i+1 {
+			t.Errorf("expected %d records before closing the recorder, got %d", i+1, records)
+		}
+	}
+}
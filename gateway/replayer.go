@@ -0,0 +1,236 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Replayer is a Gateway which replays a recording written by a Recorder instead of connecting to Discord.
+// This is useful to reproduce bugs in caches or event listeners offline, e.g. by passing bot.EventManager.HandleGatewayEvent as EventHandlerFunc.
+type Replayer interface {
+	Gateway
+
+	// Wait blocks until the replay finished or the context is done.
+	// It returns the error which stopped the replay, if any.
+	Wait(ctx context.Context) error
+}
+
+var _ Replayer = (*replayerImpl)(nil)
+
+// NewReplayer creates a new Replayer which replays the dispatch events of the recording read from r to the eventHandlerFunc.
+// The replay is started by Open and can only be done once.
+// The Gateway passed to the eventHandlerFunc returns the shard ID of the replayed Record.
+// Messages sent with Send are discarded.
+func NewReplayer(r io.Reader, eventHandlerFunc EventHandlerFunc, opts ...ReplayerConfigOpt) Replayer {
+	cfg := defaultReplayerConfig()
+	cfg.apply(opts)
+
+	return &replayerImpl{
+		config:           cfg,
+		r:                r,
+		eventHandlerFunc: eventHandlerFunc,
+		status:           StatusUnconnected,
+		done:             make(chan struct{}),
+	}
+}
+
+type replayerImpl struct {
+	config           replayerConfig
+	r                io.Reader
+	eventHandlerFunc EventHandlerFunc
+
+	mu                   sync.Mutex
+	status               Status
+	cancel               context.CancelFunc
+	sessionID            *string
+	lastSequenceReceived *int
+	resumeURL            *string
+
+	done chan struct{}
+	err  error
+}
+
+// replayShard is the Gateway passed to the EventHandlerFunc for records of a specific shard.
+type replayShard struct {
+	*replayerImpl
+	shardID int
+}
+
+func (s *replayShard) ShardID() int {
+	return s.shardID
+}
+
+func (r *replayerImpl) ShardID() int {
+	if len(r.config.ShardIDs) > 0 {
+		return r.config.ShardIDs[0]
+	}
+	return 0
+}
+
+func (r *replayerImpl) ShardCount() int {
+	return r.config.ShardCount
+}
+
+func (r *replayerImpl) SessionID() *string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessionID
+}
+
+func (r *replayerImpl) LastSequenceReceived() *int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastSequenceReceived
+}
+
+func (r *replayerImpl) ResumeURL() *string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.resumeURL
+}
+
+func (r *replayerImpl) Intents() Intents {
+	return IntentsNone
+}
+
+func (r *replayerImpl) Open(_ context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.status != StatusUnconnected {
+		return discord.ErrGatewayAlreadyConnected
+	}
+	r.status = StatusReady
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	go r.replay(ctx)
+	return nil
+}
+
+func (r *replayerImpl) replay(ctx context.Context) {
+	defer func() {
+		r.mu.Lock()
+		r.status = StatusDisconnected
+		r.mu.Unlock()
+		close(r.done)
+	}()
+
+	shards := map[int]*replayShard{}
+	var last time.Time
+	for record, err := range ReadRecords(r.r) {
+		if err != nil {
+			r.config.Logger.Error("failed to read record", slog.Any("err", err))
+			r.err = err
+			return
+		}
+		if len(r.config.ShardIDs) > 0 && !slices.Contains(r.config.ShardIDs, record.ShardID) {
+			continue
+		}
+
+		if r.config.Speed > 0 && !last.IsZero() {
+			if delay := time.Duration(float64(record.Time.Sub(last)) / r.config.Speed); delay > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					r.err = ctx.Err()
+					return
+				case <-timer.C:
+				}
+			}
+		}
+		last = record.Time
+		if ctx.Err() != nil {
+			r.err = ctx.Err()
+			return
+		}
+
+		if record.Op != OpcodeDispatch {
+			continue
+		}
+		shard, ok := shards[record.ShardID]
+		if !ok {
+			shard = &replayShard{replayerImpl: r, shardID: record.ShardID}
+			shards[record.ShardID] = shard
+		}
+		r.handle(shard, record)
+	}
+}
+
+func (r *replayerImpl) handle(shard *replayShard, record Record) {
+	eventData, err := UnmarshalEventData(record.D, record.T)
+	if err != nil {
+		r.config.Logger.Error("failed to unmarshal recorded event", slog.Any("err", err), slog.String("event", string(record.T)), slog.Int("sequence", record.S))
+		return
+	}
+
+	r.mu.Lock()
+	sequence := record.S
+	r.lastSequenceReceived = &sequence
+	if readyEvent, ok := eventData.(EventReady); ok {
+		r.sessionID = &readyEvent.SessionID
+		r.resumeURL = &readyEvent.ResumeGatewayURL
+	}
+	r.mu.Unlock()
+
+	if r.config.EnableRawEvents {
+		r.eventHandlerFunc(shard, EventTypeRaw, record.S, EventRaw{
+			EventType: record.T,
+			Payload:   bytes.NewReader(record.D),
+		})
+	}
+	if _, ok := eventData.(EventUnknown); ok {
+		return
+	}
+	r.eventHandlerFunc(shard, record.T, record.S, eventData)
+}
+
+func (r *replayerImpl) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-r.done:
+		return r.err
+	}
+}
+
+func (r *replayerImpl) Close(ctx context.Context) {
+	r.CloseWithCode(ctx, 0, "")
+}
+
+func (r *replayerImpl) CloseWithCode(ctx context.Context, _ int, _ string) {
+	r.mu.Lock()
+	cancel := r.cancel
+	r.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	_ = r.Wait(ctx)
+}
+
+func (r *replayerImpl) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+func (r *replayerImpl) Send(_ context.Context, op Opcode, _ MessageData) error {
+	r.config.Logger.Debug("discarding message sent to replayer", slog.Int("op", int(op)))
+	return nil
+}
+
+func (r *replayerImpl) Latency() time.Duration {
+	return 0
+}
+
+func (r *replayerImpl) Presence() *MessageDataPresenceUpdate {
+	return nil
+}
@@ -0,0 +1,72 @@
+package gateway
+
+import (
+	"log/slog"
+)
+
+func defaultReplayerConfig() replayerConfig {
+	return replayerConfig{
+		Logger:     slog.Default(),
+		Speed:      1,
+		ShardCount: 1,
+	}
+}
+
+type replayerConfig struct {
+	// Logger is the Logger of the Replayer. Defaults to slog.Default().
+	Logger *slog.Logger
+	// Speed is the factor the original timing of the recording is sped up by. 0 replays as fast as possible. Defaults to 1.
+	Speed float64
+	// ShardIDs are the shards whose records are replayed. Defaults to nil (all shards).
+	ShardIDs []int
+	// ShardCount is the shard count returned by Gateway.ShardCount. Defaults to 1.
+	ShardCount int
+	// EnableRawEvents is whether the Replayer should also emit EventTypeRaw. Defaults to false.
+	EnableRawEvents bool
+}
+
+// ReplayerConfigOpt is a type alias for a function that takes a replayerConfig and is used to configure your Replayer.
+type ReplayerConfigOpt func(config *replayerConfig)
+
+func (c *replayerConfig) apply(opts []ReplayerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "gateway_replayer"))
+}
+
+// WithReplayerLogger sets the Logger of the Replayer.
+func WithReplayerLogger(logger *slog.Logger) ReplayerConfigOpt {
+	return func(config *replayerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithReplaySpeed sets the factor the original timing of the recording is sped up by.
+// 1 replays at the original speed, 0 replays as fast as possible.
+func WithReplaySpeed(speed float64) ReplayerConfigOpt {
+	return func(config *replayerConfig) {
+		config.Speed = speed
+	}
+}
+
+// WithReplayShardIDs only replays the records of the given shards.
+func WithReplayShardIDs(shardIDs ...int) ReplayerConfigOpt {
+	return func(config *replayerConfig) {
+		config.ShardIDs = shardIDs
+	}
+}
+
+// WithReplayShardCount sets the shard count the Replayer reports.
+func WithReplayShardCount(shardCount int) ReplayerConfigOpt {
+	return func(config *replayerConfig) {
+		config.ShardCount = shardCount
+	}
+}
+
+// WithReplayRawEvents enables/disables the EventTypeRaw.
+func WithReplayRawEvents(enableRawEvents bool) ReplayerConfigOpt {
+	return func(config *replayerConfig) {
+		config.EnableRawEvents = enableRawEvents
+	}
+}
@@ -0,0 +1,187 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/disgoorg/json/v2"
+)
+
+// Session is the state required to resume a Gateway session.
+type Session struct {
+	// ID is the session ID received in the EventReady.
+	ID string `json:"id"`
+	// Sequence is the last sequence received.
+	Sequence int `json:"sequence"`
+	// ResumeURL is the resume gateway URL received in the EventReady.
+	ResumeURL string `json:"resume_url"`
+	// ShardCount is the shard count the session was created with. Sessions with a different shard count are not resumed.
+	ShardCount int `json:"shard_count"`
+}
+
+// SessionStore persists the Session of Gateway(s) by shard ID, so they can resume after a restart instead of identifying again.
+// The Gateway loads its Session on Open and stores it on EventReady, EventResumed, every heartbeat and when the connection is closed.
+// The sequence is not stored on every dispatch, so after a crash the stored Session can be up to one heartbeat interval behind
+// and the events received in that time are sent again after resuming.
+// Sessions are deleted when they are invalidated or the connection is closed with websocket.CloseNormalClosure or websocket.CloseGoingAway.
+// Gateway.Close keeps the session by closing with websocket.CloseServiceRestart, so the Gateway resumes after a restart.
+type SessionStore interface {
+	// Get returns the Session of the shard or nil if there is none.
+	Get(ctx context.Context, shardID int) (*Session, error)
+	// Set stores the Session of the shard.
+	Set(ctx context.Context, shardID int, session Session) error
+	// Delete deletes the Session of the shard.
+	Delete(ctx context.Context, shardID int) error
+}
+
+var (
+	_ SessionStore = (*memorySessionStore)(nil)
+	_ SessionStore = (*fileSessionStore)(nil)
+)
+
+// NewMemorySessionStore returns a SessionStore which keeps the sessions in memory.
+// This is useful to share sessions between Gateway(s) which are recreated in the same process.
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{
+		sessions: map[int]Session{},
+	}
+}
+
+type memorySessionStore struct {
+	sessions map[int]Session
+	mu       sync.Mutex
+}
+
+func (s *memorySessionStore) Get(_ context.Context, shardID int) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[shardID]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (s *memorySessionStore) Set(_ context.Context, shardID int, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[shardID] = session
+	return nil
+}
+
+func (s *memorySessionStore) Delete(_ context.Context, shardID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, shardID)
+	return nil
+}
+
+// NewFileSessionStore returns a SessionStore which stores the sessions of all shards as JSON in the file at path.
+// The file is created on the first write and replaced atomically, so it is never left partially written.
+func NewFileSessionStore(path string) SessionStore {
+	return &fileSessionStore{
+		path: path,
+	}
+}
+
+type fileSessionStore struct {
+	path     string
+	sessions map[int]Session
+	mu       sync.Mutex
+}
+
+// load reads the file once. It must be called with the lock held.
+func (s *fileSessionStore) load() error {
+	if s.sessions != nil {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.sessions = map[int]Session{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var sessions map[string]Session
+	if err = json.Unmarshal(data, &sessions); err != nil {
+		return err
+	}
+	s.sessions = make(map[int]Session, len(sessions))
+	for key, session := range sessions {
+		shardID, err := strconv.Atoi(key)
+		if err != nil {
+			return err
+		}
+		s.sessions[shardID] = session
+	}
+	return nil
+}
+
+// save writes all sessions to a temporary file and renames it to the path. It must be called with the lock held.
+func (s *fileSessionStore) save() error {
+	data, err := json.Marshal(s.sessions)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+func (s *fileSessionStore) Get(_ context.Context, shardID int) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	session, ok := s.sessions[shardID]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (s *fileSessionStore) Set(_ context.Context, shardID int, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	if current, ok := s.sessions[shardID]; ok && current == session {
+		return nil
+	}
+	s.sessions[shardID] = session
+	return s.save()
+}
+
+func (s *fileSessionStore) Delete(_ context.Context, shardID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.sessions[shardID]; !ok {
+		return nil
+	}
+	delete(s.sessions, shardID)
+	return s.save()
+}
@@ -0,0 +1,74 @@
+package gateway
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSessionStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store := NewFileSessionStore(path)
+
+	session, err := store.Get(ctx, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session != nil {
+		t.Fatalf("expected no session, got %+v", session)
+	}
+
+	want := Session{ID: "abc", Sequence: 42, ResumeURL: "wss://gateway.discord.gg", ShardCount: 2}
+	if err = store.Set(ctx, 1, want); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = store.Set(ctx, 0, Session{ID: "def", Sequence: 1, ShardCount: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = store.Delete(ctx, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a new store must read the sessions written by the previous one
+	store = NewFileSessionStore(path)
+	session, err = store.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session == nil || *session != want {
+		t.Errorf("expected %+v, got %+v", want, session)
+	}
+	if session, _ = store.Get(ctx, 0); session != nil {
+		t.Errorf("expected deleted session, got %+v", session)
+	}
+}
+
+func TestGateway_LoadSession(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemorySessionStore()
+	_ = store.Set(ctx, 1, Session{ID: "abc", Sequence: 42, ResumeURL: "wss://resume.discord.gg", ShardCount: 2})
+
+	g := New("", nil, WithShardID(1), WithShardCount(2), WithSessionStore(store)).(*gatewayImpl)
+	g.loadSession(ctx)
+	if g.SessionID() == nil || *g.SessionID() != "abc" || *g.LastSequenceReceived() != 42 || *g.ResumeURL() != "wss://resume.discord.gg" {
+		t.Errorf("expected stored session to be loaded")
+	}
+
+	// sessions of a different shard count can't be resumed
+	g = New("", nil, WithShardID(1), WithShardCount(4), WithSessionStore(store)).(*gatewayImpl)
+	g.loadSession(ctx)
+	if g.SessionID() != nil {
+		t.Errorf("expected session of different shard count to be ignored")
+	}
+
+	g.config.SessionID = nil
+	g.storeSession(ctx)
+	if session, _ := store.Get(ctx, 1); session != nil {
+		t.Errorf("expected session without id to be deleted, got %+v", session)
+	}
+}
@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// commandFields are the fields of an application command which are compared by SyncCommandsDiff.
+var commandFields = []string{
+	"type",
+	"name",
+	"name_localizations",
+	"description",
+	"description_localizations",
+	"options",
+	"default_member_permissions",
+	"integration_types",
+	"contexts",
+	"nsfw",
+	"handler",
+}
+
+// defaultedCommandFields are only compared if they are set in the desired command, as Discord fills them with the defaults of the application otherwise.
+var defaultedCommandFields = []string{"integration_types", "contexts"}
+
+// CommandSyncAction is the action SyncCommandsDiff takes for a command.
+type CommandSyncAction int
+
+// All CommandSyncAction(s)
+const (
+	CommandSyncActionCreate CommandSyncAction = iota
+	CommandSyncActionUpdate
+	CommandSyncActionDelete
+)
+
+func (a CommandSyncAction) String() string {
+	switch a {
+	case CommandSyncActionCreate:
+		return "create"
+	case CommandSyncActionUpdate:
+		return "update"
+	case CommandSyncActionDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// CommandSyncChange is a command which is created, updated or deleted by SyncCommandsDiff.
+type CommandSyncChange struct {
+	Action CommandSyncAction
+	// GuildID is the guild of the command or nil for global commands.
+	GuildID *snowflake.ID
+	Type    discord.ApplicationCommandType
+	Name    string
+	// CommandID is the ID of the existing command or 0 for created commands.
+	CommandID snowflake.ID
+}
+
+// CommandSyncReport lists the changes of SyncCommandsDiff.
+type CommandSyncReport struct {
+	// Changes are the changes which were applied or, in a dry run, would be applied.
+	Changes []CommandSyncChange
+}
+
+// CommandSyncError is returned by SyncCommandsDiff for each guild which could not be synced.
+type CommandSyncError struct {
+	// GuildID is the guild which failed or nil for global commands.
+	GuildID *snowflake.ID
+	Err     error
+}
+
+func (e *CommandSyncError) Error() string {
+	if e.GuildID == nil {
+		return fmt.Sprintf("failed to sync global commands: %s", e.Err)
+	}
+	return fmt.Sprintf("failed to sync commands of guild %s: %s", *e.GuildID, e.Err)
+}
+
+func (e *CommandSyncError) Unwrap() error {
+	return e.Err
+}
+
+// SyncCommandsDiff syncs the given commands for the given guilds or globally if guildIDs is empty.
+// Unlike SyncCommands, it fetches the existing commands and only creates, updates or deletes the commands which differ,
+// so the IDs & permissions of unchanged commands are kept.
+// If dryRun is true, no changes are applied and the report lists the changes which would be applied.
+// Failing guilds don't stop the sync, the returned error joins a *CommandSyncError for each of them.
+func SyncCommandsDiff(client *bot.Client, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, dryRun bool, opts ...rest.RequestOpt) (CommandSyncReport, error) {
+	var report CommandSyncReport
+	if len(guildIDs) == 0 {
+		changes, err := syncCommandsDiff(client, commands, nil, dryRun, opts)
+		report.Changes = changes
+		if err != nil {
+			return report, &CommandSyncError{Err: err}
+		}
+		return report, nil
+	}
+
+	var errs []error
+	for _, guildID := range guildIDs {
+		changes, err := syncCommandsDiff(client, commands, &guildID, dryRun, opts)
+		report.Changes = append(report.Changes, changes...)
+		if err != nil {
+			errs = append(errs, &CommandSyncError{GuildID: &guildID, Err: err})
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+type existingCommand struct {
+	id     snowflake.ID
+	fields map[string]any
+}
+
+type commandKey struct {
+	t    discord.ApplicationCommandType
+	name string
+}
+
+// syncCommandsDiff syncs the commands of a single guild or the global commands if guildID is nil.
+// It returns the applied changes and continues after a failed change.
+func syncCommandsDiff(client *bot.Client, commands []discord.ApplicationCommandCreate, guildID *snowflake.ID, dryRun bool, opts []rest.RequestOpt) ([]CommandSyncChange, error) {
+	endpoint := rest.GetGlobalCommands.Compile(discord.QueryValues{"with_localizations": true}, client.ApplicationID)
+	if guildID != nil {
+		endpoint = rest.GetGuildCommands.Compile(discord.QueryValues{"with_localizations": true}, client.ApplicationID, *guildID)
+	}
+	// the raw commands are compared, as discord.ApplicationCommand doesn't differentiate between a null & 0 default_member_permissions
+	var rawCommands []json.RawMessage
+	if err := client.Rest.Do(endpoint, nil, &rawCommands, opts...); err != nil {
+		return nil, err
+	}
+
+	existing := make(map[commandKey]existingCommand, len(rawCommands))
+	for _, rawCommand := range rawCommands {
+		var command struct {
+			ID snowflake.ID `json:"id"`
+		}
+		if err := json.Unmarshal(rawCommand, &command); err != nil {
+			return nil, err
+		}
+		fields, err := commandSyncFields(rawCommand)
+		if err != nil {
+			return nil, err
+		}
+		existing[commandSyncKey(fields)] = existingCommand{id: command.ID, fields: fields}
+	}
+
+	var changes []CommandSyncChange
+	desired := make(map[commandKey]discord.ApplicationCommandCreate, len(commands))
+	for _, command := range commands {
+		rawCommand, err := json.Marshal(command)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := commandSyncFields(rawCommand)
+		if err != nil {
+			return nil, err
+		}
+		key := commandSyncKey(fields)
+		desired[key] = command
+
+		change := CommandSyncChange{
+			Action:  CommandSyncActionCreate,
+			GuildID: guildID,
+			Type:    key.t,
+			Name:    key.name,
+		}
+		if existingCommand, ok := existing[key]; ok {
+			existingFields := existingCommand.fields
+			for _, field := range defaultedCommandFields {
+				if _, ok = fields[field]; !ok {
+					delete(existingFields, field)
+				}
+			}
+			if reflect.DeepEqual(fields, existingFields) {
+				continue
+			}
+			change.Action = CommandSyncActionUpdate
+			change.CommandID = existingCommand.id
+		}
+		changes = append(changes, change)
+	}
+	var deletes []CommandSyncChange
+	for key, command := range existing {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		deletes = append(deletes, CommandSyncChange{
+			Action:    CommandSyncActionDelete,
+			GuildID:   guildID,
+			Type:      key.t,
+			Name:      key.name,
+			CommandID: command.id,
+		})
+	}
+	slices.SortFunc(deletes, func(a, b CommandSyncChange) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	changes = append(changes, deletes...)
+
+	if dryRun {
+		return changes, nil
+	}
+
+	var (
+		applied []CommandSyncChange
+		errs    []error
+	)
+	for _, change := range changes {
+		var err error
+		switch change.Action {
+		case CommandSyncActionCreate, CommandSyncActionUpdate:
+			// creating a command with the name of an existing one overwrites it and keeps its ID
+			command := desired[commandKey{t: change.Type, name: change.Name}]
+			if guildID == nil {
+				_, err = client.Rest.CreateGlobalCommand(client.ApplicationID, command, opts...)
+			} else {
+				_, err = client.Rest.CreateGuildCommand(client.ApplicationID, *guildID, command, opts...)
+			}
+		case CommandSyncActionDelete:
+			if guildID == nil {
+				err = client.Rest.DeleteGlobalCommand(client.ApplicationID, change.CommandID, opts...)
+			} else {
+				err = client.Rest.DeleteGuildCommand(client.ApplicationID, *guildID, change.CommandID, opts...)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to %s command %s: %w", change.Action, change.Name, err))
+			continue
+		}
+		applied = append(applied, change)
+	}
+	return applied, errors.Join(errs...)
+}
+
+// commandSyncFields returns the comparable fields of a raw command with empty values removed.
+func commandSyncFields(rawCommand []byte) (map[string]any, error) {
+	var v map[string]any
+	if err := json.Unmarshal(rawCommand, &v); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]any, len(commandFields))
+	for _, field := range commandFields {
+		if value := normalizeCommandValue(v[field]); value != nil {
+			fields[field] = value
+		}
+	}
+	if _, ok := fields["type"]; !ok {
+		fields["type"] = float64(discord.ApplicationCommandTypeSlash)
+	}
+	return fields, nil
+}
+
+// normalizeCommandValue removes all values which are equal to omitting them, so they don't cause false differences.
+func normalizeCommandValue(value any) any {
+	switch v := value.(type) {
+	case bool:
+		if !v {
+			return nil
+		}
+	case string:
+		if v == "" {
+			return nil
+		}
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		for i := range v {
+			v[i] = normalizeCommandValue(v[i])
+		}
+	case map[string]any:
+		for key, vv := range v {
+			if vv = normalizeCommandValue(vv); vv == nil {
+				delete(v, key)
+			} else {
+				v[key] = vv
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+	}
+	return value
+}
+
+func commandSyncKey(fields map[string]any) commandKey {
+	t, _ := fields["type"].(float64)
+	name, _ := fields["name"].(string)
+	return commandKey{t: discord.ApplicationCommandType(t), name: name}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/resttest"
+)
+
+func TestSyncCommandsDiff(t *testing.T) {
+	fake := resttest.NewServer()
+	defer fake.Close()
+
+	client := &bot.Client{
+		ApplicationID: fake.ApplicationID(),
+		Rest:          rest.New(rest.NewClient("token", rest.WithURL(fake.URL), rest.WithRateLimiter(rest.NewNoopRateLimiter()))),
+	}
+
+	existing, err := client.Rest.SetGlobalCommands(client.ApplicationID, []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{Name: "ping", Description: "Ping"},
+		discord.SlashCommandCreate{Name: "old", Description: "Old"},
+		discord.SlashCommandCreate{Name: "same", Description: "Same"},
+		discord.UserCommandCreate{Name: "info"},
+	})
+	if err != nil {
+		t.Fatalf("failed to set commands: %v", err)
+	}
+
+	commands := []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{Name: "ping", Description: "Pong"},
+		discord.SlashCommandCreate{Name: "same", Description: "Same"},
+		discord.SlashCommandCreate{Name: "new", Description: "New"},
+		discord.UserCommandCreate{Name: "info"},
+	}
+	expected := []CommandSyncChange{
+		{Action: CommandSyncActionUpdate, Type: discord.ApplicationCommandTypeSlash, Name: "ping", CommandID: existing[0].ID()},
+		{Action: CommandSyncActionCreate, Type: discord.ApplicationCommandTypeSlash, Name: "new"},
+		{Action: CommandSyncActionDelete, Type: discord.ApplicationCommandTypeSlash, Name: "old", CommandID: existing[1].ID()},
+	}
+
+	report, err := SyncCommandsDiff(client, commands, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(report.Changes, expected) {
+		t.Fatalf("expected dry run changes %+v, got %+v", expected, report.Changes)
+	}
+	if got, _ := client.Rest.GetGlobalCommands(client.ApplicationID, false); len(got) != 4 {
+		t.Fatalf("expected dry run to keep 4 commands, got %d", len(got))
+	}
+
+	if report, err = SyncCommandsDiff(client, commands, nil, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(report.Changes, expected) {
+		t.Fatalf("expected changes %+v, got %+v", expected, report.Changes)
+	}
+
+	got, err := client.Rest.GetGlobalCommands(client.ApplicationID, false)
+	if err != nil {
+		t.Fatalf("failed to get commands: %v", err)
+	}
+	names := make([]string, len(got))
+	for i, command := range got {
+		names[i] = command.Name()
+		if command.Name() == "ping" && (command.ID() != existing[0].ID() || command.(discord.SlashCommand).Description != "Pong") {
+			t.Errorf("expected ping to be updated in place, got %+v", command)
+		}
+	}
+	slices.Sort(names)
+	if want := []string{"info", "new", "ping", "same"}; !slices.Equal(names, want) {
+		t.Errorf("expected commands %v, got %v", want, names)
+	}
+
+	if report, err = SyncCommandsDiff(client, commands, nil, false); err != nil || len(report.Changes) != 0 {
+		t.Errorf("expected no changes, got %+v & %v", report.Changes, err)
+	}
+}
@@ -202,6 +202,13 @@ func HandleInteraction(verifier Verifier, publicKey PublicKey, logger *slog.Logg
 		if multiPart, ok := body.(*discord.MultipartBuffer); ok {
 			w.Header().Set("Content-Type", multiPart.ContentType)
 			_, err = io.Copy(rsWriter, multiPart.Buffer)
+		} else if multiPart, ok := body.(*discord.MultipartStream); ok {
+			w.Header().Set("Content-Type", multiPart.ContentType)
+			var multiPartBody io.ReadCloser
+			if multiPartBody, err = multiPart.Open(); err == nil {
+				_, err = io.Copy(rsWriter, multiPartBody)
+				_ = multiPartBody.Close()
+			}
 		} else {
 			w.Header().Set("Content-Type", "application/json")
 			err = json.NewEncoder(rsWriter).Encode(body)
@@ -127,6 +127,21 @@ func (c *Client) RefreshSession(session Session, opts ...rest.RequestOpt) (Sessi
 	return newSession(*accessToken), nil
 }
 
+// ClientCredentials returns a Session of the application's owner with the given scopes using the client credentials grant.
+// The Session has no refresh token, request a new one once it expired or use ClientCredentialsTokenSource.
+func (c *Client) ClientCredentials(scopes []discord.OAuth2Scope, opts ...rest.RequestOpt) (Session, error) {
+	accessToken, err := c.Rest.GetClientCredentialsAccessToken(c.ID, c.Secret, scopes, opts...)
+	if err != nil {
+		return Session{}, err
+	}
+	return newSession(*accessToken), nil
+}
+
+// RevokeToken revokes the given access or refresh token. Revoking either invalidates the whole Session.
+func (c *Client) RevokeToken(token string, opts ...rest.RequestOpt) error {
+	return c.Rest.RevokeToken(c.ID, c.Secret, token, opts...)
+}
+
 func (c *Client) VerifySession(session Session, opts ...rest.RequestOpt) (Session, error) {
 	if session.Expired() {
 		return c.RefreshSession(session, opts...)
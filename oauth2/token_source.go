@@ -0,0 +1,90 @@
+package oauth2
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// tokenRefreshMargin is how long before its expiration a Session is refreshed by a TokenSource.
+const tokenRefreshMargin = time.Minute
+
+// TokenSource returns a valid Session and refreshes it shortly before it expires.
+// It implements rest.TokenSource, so it can authorize requests with rest.WithTokenSource:
+//
+//	user, err := client.Rest.GetCurrentUser("", rest.WithTokenSource(tokenSource))
+type TokenSource interface {
+	rest.TokenSource
+
+	// Session returns the current Session and refreshes it first if it expires within a minute.
+	Session(ctx context.Context) (Session, error)
+}
+
+// TokenSource returns a TokenSource starting with the given Session.
+// Sessions with a refresh token are refreshed with RefreshSession, others are replaced with ClientCredentials using the scopes of the Session.
+// onRefresh is called with every new Session, e.g. to persist it, and may be nil.
+func (c *Client) TokenSource(session Session, onRefresh func(session Session)) TokenSource {
+	return &tokenSourceImpl{
+		client:    c,
+		session:   session,
+		onRefresh: onRefresh,
+	}
+}
+
+// ClientCredentialsTokenSource returns a TokenSource which requests a Session with the given scopes using ClientCredentials when it's first used and once it expires.
+func (c *Client) ClientCredentialsTokenSource(scopes ...discord.OAuth2Scope) TokenSource {
+	return c.TokenSource(Session{Scopes: scopes}, nil)
+}
+
+var _ TokenSource = (*tokenSourceImpl)(nil)
+
+type tokenSourceImpl struct {
+	client    *Client
+	onRefresh func(session Session)
+
+	mu      sync.Mutex
+	session Session
+}
+
+func (s *tokenSourceImpl) Session(ctx context.Context) (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session.AccessToken != "" && time.Now().Add(tokenRefreshMargin).Before(s.session.Expiration) {
+		return s.session, nil
+	}
+
+	var (
+		session Session
+		err     error
+	)
+	if s.session.RefreshToken != "" {
+		session, err = s.client.RefreshSession(s.session, rest.WithCtx(ctx))
+	} else {
+		session, err = s.client.ClientCredentials(s.session.Scopes, rest.WithCtx(ctx))
+	}
+	if err != nil {
+		return Session{}, err
+	}
+
+	s.session = session
+	if s.onRefresh != nil {
+		s.onRefresh(session)
+	}
+	return session, nil
+}
+
+func (s *tokenSourceImpl) Token(ctx context.Context) (discord.TokenType, string, error) {
+	session, err := s.Session(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	tokenType := session.TokenType
+	if tokenType == "" {
+		tokenType = discord.TokenTypeBearer
+	}
+	return tokenType, session.AccessToken, nil
+}
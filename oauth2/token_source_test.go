@@ -0,0 +1,67 @@
+package oauth2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestClient_ClientCredentialsTokenSource(t *testing.T) {
+	var tokenRequests, revokeRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth2/token":
+			tokenRequests.Add(1)
+			if grantType, scope := r.FormValue("grant_type"), r.FormValue("scope"); grantType != "client_credentials" || scope != "identify" {
+				t.Errorf("unexpected grant type %q or scope %q", grantType, scope)
+			}
+			_, _ = w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":604800,"scope":"identify"}`))
+		case "/oauth2/token/revoke":
+			revokeRequests.Add(1)
+			if token := r.FormValue("token"); token != "access" {
+				t.Errorf("unexpected revoked token %q", token)
+			}
+		case "/users/@me":
+			if auth := r.Header.Get("Authorization"); auth != "Bearer access" {
+				t.Errorf("unexpected authorization %q", auth)
+			}
+			_, _ = w.Write([]byte(`{"id":"1","username":"owner"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := New(1, "secret", WithRestClientConfigOpts(rest.WithURL(server.URL), rest.WithRateLimiter(rest.NewNoopRateLimiter())))
+	tokenSource := client.ClientCredentialsTokenSource(discord.OAuth2ScopeIdentify)
+
+	for range 2 {
+		user, err := client.Rest.GetCurrentUser("", rest.WithTokenSource(tokenSource))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.ID != 1 {
+			t.Errorf("expected user 1, got %d", user.ID)
+		}
+	}
+	if got := tokenRequests.Load(); got != 1 {
+		t.Errorf("expected 1 token request, got %d", got)
+	}
+
+	session, err := tokenSource.Session(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = client.RevokeToken(session.AccessToken); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := revokeRequests.Load(); got != 1 {
+		t.Errorf("expected 1 revoke request, got %d", got)
+	}
+}
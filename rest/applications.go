@@ -1,6 +1,9 @@
 package rest
 
 import (
+	"context"
+	"iter"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -38,6 +41,9 @@ type Applications interface {
 	UpdateApplicationRoleConnectionMetadata(applicationID snowflake.ID, newRecords []discord.ApplicationRoleConnectionMetadata, opts ...RequestOpt) ([]discord.ApplicationRoleConnectionMetadata, error)
 
 	GetEntitlements(applicationID snowflake.ID, params GetEntitlementsParams, opts ...RequestOpt) ([]discord.Entitlement, error)
+	// EntitlementsIter returns an iterator over the entitlements matching params in the given direction, starting at params.Before or params.After.
+	// params.Limit is ignored. It stops after limit entitlements, or never if limit is 0.
+	EntitlementsIter(ctx context.Context, applicationID snowflake.ID, params GetEntitlementsParams, direction PageDirection, limit int, opts ...RequestOpt) iter.Seq2[discord.Entitlement, error]
 	GetEntitlement(applicationID snowflake.ID, entitlementID snowflake.ID, opts ...RequestOpt) (*discord.Entitlement, error)
 	CreateTestEntitlement(applicationID snowflake.ID, entitlementCreate discord.TestEntitlementCreate, opts ...RequestOpt) (*discord.Entitlement, error)
 	DeleteTestEntitlement(applicationID snowflake.ID, entitlementID snowflake.ID, opts ...RequestOpt) error
@@ -227,6 +233,22 @@ func (s *applicationsImpl) GetEntitlements(applicationID snowflake.ID, params Ge
 	return
 }
 
+func (s *applicationsImpl) EntitlementsIter(ctx context.Context, applicationID snowflake.ID, params GetEntitlementsParams, direction PageDirection, limit int, opts ...RequestOpt) iter.Seq2[discord.Entitlement, error] {
+	startID := params.Before
+	if direction == PageDirectionForward {
+		startID = params.After
+	}
+	params.Limit = 100
+	opts = withIterCtx(ctx, opts)
+	return iterPages(ctx, direction, startID, limit, func(before snowflake.ID, after snowflake.ID) ([]discord.Entitlement, error) {
+		params.Before = before
+		params.After = after
+		return s.GetEntitlements(applicationID, params, opts...)
+	}, func(entitlement discord.Entitlement) snowflake.ID {
+		return entitlement.ID
+	})
+}
+
 func (s *applicationsImpl) GetEntitlement(applicationID snowflake.ID, entitlementID snowflake.ID, opts ...RequestOpt) (entitlement *discord.Entitlement, err error) {
 	err = s.client.Do(GetEntitlement.Compile(nil, applicationID, entitlementID), nil, &entitlement, opts...)
 	return
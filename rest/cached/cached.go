@@ -0,0 +1,690 @@
+package cached
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ rest.Rest = (*restImpl)(nil)
+
+// New returns a rest.Rest which serves guilds, channels, roles, members, emojis & stickers from the given cache.Caches if possible.
+// On a cache miss, the entity is fetched from Discord and put into the caches according to their cache.Policy(s).
+// Entities changed through the returned rest.Rest are updated in the caches as well.
+//
+// Entities received from the gateway are always served from the caches, while fetched entities are served until the duration set with WithMaxStaleness passes.
+// Lists of guild channels, roles, emojis & stickers are only served from the caches if they are known to be complete,
+// which is the case for guilds received from the gateway with the corresponding cache.Flags enabled or lists fetched before.
+func New(r rest.Rest, caches cache.Caches, opts ...ConfigOpt) rest.Rest {
+	cfg := defaultConfig()
+	cfg.apply(opts)
+
+	return &restImpl{
+		Rest:    r,
+		caches:  caches,
+		config:  cfg,
+		fetched: map[entityKey]time.Time{},
+	}
+}
+
+type entityKind int
+
+const (
+	kindGuild entityKind = iota
+	kindChannel
+	kindRole
+	kindMember
+	kindEmoji
+	kindSticker
+	kindGuildChannels
+	kindRoles
+	kindEmojis
+	kindStickers
+)
+
+// entityKey identifies an entity or a list of entities of a guild fetched from Discord.
+type entityKey struct {
+	kind    entityKind
+	groupID snowflake.ID
+	id      snowflake.ID
+}
+
+type restImpl struct {
+	rest.Rest
+	caches cache.Caches
+	config config
+
+	mu sync.Mutex
+	// fetched contains when entities & lists were fetched from Discord. Cached entities without an entry are kept up to date by the gateway.
+	fetched map[entityKey]time.Time
+	// lastSweep is when fetched was last cleared of entries for evicted or stale entities
+	lastSweep time.Time
+}
+
+// sweepInterval is how often fetched entries are checked for entities which were evicted from the caches or became stale.
+const sweepInterval = time.Minute
+
+func (r *restImpl) expired(fetchedAt time.Time) bool {
+	return r.config.MaxStaleness > 0 && time.Since(fetchedAt) >= r.config.MaxStaleness
+}
+
+// fresh reports whether a cached entity can be served.
+func (r *restImpl) fresh(key entityKey) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fetchedAt, ok := r.fetched[key]
+	return !ok || !r.expired(fetchedAt)
+}
+
+// complete reports whether the caches contain all entities of the list with the given kind of a guild.
+func (r *restImpl) complete(kind entityKind, guildID snowflake.ID, flag cache.Flags) bool {
+	r.mu.Lock()
+	fetchedAt, listFetched := r.fetched[entityKey{kind: kind, id: guildID}]
+	_, guildFetched := r.fetched[entityKey{kind: kindGuild, id: guildID}]
+	r.mu.Unlock()
+	if listFetched {
+		return !r.expired(fetchedAt)
+	}
+
+	// guilds received from the gateway come with all their channels, roles, emojis & stickers
+	if guildFetched {
+		return false
+	}
+	if !r.caches.CacheFlags().Has(cache.FlagGuilds, flag) || r.caches.IsGuildUnavailable(guildID) {
+		return false
+	}
+	_, ok := r.caches.Guild(guildID)
+	return ok
+}
+
+// track records that an entity or list was fetched from Discord.
+// Entities which were cached before without being fetched are kept up to date by the gateway and stay untracked.
+func (r *restImpl) track(key entityKey, cachedBefore bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.fetched[key]; cachedBefore && !ok {
+		return
+	}
+	r.fetched[key] = time.Now()
+	r.sweep()
+}
+
+// sweep removes the entries of entities which were evicted from the caches at most once per sweepInterval.
+// Stale entities are removed from the caches as well, as an entity without entry would be considered to be kept up to date by the gateway.
+// Lists are only removed when they are stale. r.mu must be locked.
+func (r *restImpl) sweep() {
+	now := time.Now()
+	if now.Sub(r.lastSweep) < sweepInterval {
+		return
+	}
+	r.lastSweep = now
+
+	for key, fetchedAt := range r.fetched {
+		if r.expired(fetchedAt) {
+			delete(r.fetched, key)
+			r.evict(key)
+		} else if !r.cached(key) {
+			delete(r.fetched, key)
+		}
+	}
+}
+
+// cached reports whether the entity of the key is in the caches. Lists are always reported as cached.
+func (r *restImpl) cached(key entityKey) bool {
+	var ok bool
+	switch key.kind {
+	case kindGuild:
+		_, ok = r.caches.Guild(key.id)
+	case kindChannel:
+		_, ok = r.caches.Channel(key.id)
+	case kindRole:
+		_, ok = r.caches.RoleCache().Get(key.groupID, key.id)
+	case kindMember:
+		_, ok = r.caches.MemberCache().Get(key.groupID, key.id)
+	case kindEmoji:
+		_, ok = r.caches.EmojiCache().Get(key.groupID, key.id)
+	case kindSticker:
+		_, ok = r.caches.StickerCache().Get(key.groupID, key.id)
+	default:
+		ok = true
+	}
+	return ok
+}
+
+// evict removes the entity of the key from the caches. Lists are kept.
+func (r *restImpl) evict(key entityKey) {
+	switch key.kind {
+	case kindGuild:
+		r.caches.RemoveGuild(key.id)
+	case kindChannel:
+		r.caches.RemoveChannel(key.id)
+	case kindRole:
+		r.caches.RoleCache().Remove(key.groupID, key.id)
+	case kindMember:
+		r.caches.MemberCache().Remove(key.groupID, key.id)
+	case kindEmoji:
+		r.caches.EmojiCache().Remove(key.groupID, key.id)
+	case kindSticker:
+		r.caches.StickerCache().Remove(key.groupID, key.id)
+	}
+}
+
+func (r *restImpl) untrack(keys ...entityKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range keys {
+		delete(r.fetched, key)
+	}
+}
+
+func (r *restImpl) tracked(key entityKey) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.fetched[key]
+	return ok
+}
+
+// getGrouped returns an entity from the cache if it is fresh.
+func getGrouped[T any](r *restImpl, kind entityKind, c cache.GroupedCache[T], groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entity, ok := c.Get(groupID, id)
+	if !ok || !r.fresh(entityKey{kind: kind, groupID: groupID, id: id}) {
+		var zero T
+		return zero, false
+	}
+	return entity, true
+}
+
+// putGrouped puts a fetched entity into the cache and returns whether the cache accepted it.
+func putGrouped[T any](r *restImpl, kind entityKind, c cache.GroupedCache[T], groupID snowflake.ID, id snowflake.ID, entity T) bool {
+	key := entityKey{kind: kind, groupID: groupID, id: id}
+	_, cachedBefore := c.Get(groupID, id)
+	c.Put(groupID, id, entity)
+	if _, ok := c.Get(groupID, id); !ok {
+		r.untrack(key)
+		return false
+	}
+	r.track(key, cachedBefore)
+	return true
+}
+
+// putGroupedList replaces all entities of a guild with the fetched ones and marks the list as complete if the cache accepted all of them.
+func putGroupedList[T any](r *restImpl, kind entityKind, listKind entityKind, c cache.GroupedCache[T], guildID snowflake.ID, entities []T, idFunc func(T) snowflake.ID) {
+	ids := make([]snowflake.ID, len(entities))
+	complete := true
+	for i, entity := range entities {
+		ids[i] = idFunc(entity)
+		if !putGrouped(r, kind, c, guildID, ids[i], entity) {
+			complete = false
+		}
+	}
+	c.GroupRemoveIf(guildID, func(_ snowflake.ID, entity T) bool {
+		return !slices.Contains(ids, idFunc(entity))
+	})
+
+	key := entityKey{kind: listKind, id: guildID}
+	if !complete {
+		r.untrack(key)
+		return
+	}
+	r.track(key, false)
+}
+
+// removeGrouped removes a deleted entity from the cache.
+func removeGrouped[T any](r *restImpl, kind entityKind, c cache.GroupedCache[T], groupID snowflake.ID, id snowflake.ID) {
+	c.Remove(groupID, id)
+	r.untrack(entityKey{kind: kind, groupID: groupID, id: id})
+}
+
+func collectGroup[T any](c cache.GroupedCache[T], groupID snowflake.ID) []T {
+	var entities []T
+	for entity := range c.GroupAll(groupID) {
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+func (r *restImpl) putGuild(guild discord.RestGuild) {
+	cacheGuild := discord.CacheGuild{
+		Guild:       guild.Guild,
+		MemberCount: guild.ApproximateMemberCount,
+	}
+	cached, cachedBefore := r.caches.Guild(guild.ID)
+	if cachedBefore {
+		cacheGuild.JoinedAt = cached.JoinedAt
+		cacheGuild.Large = cached.Large
+		cacheGuild.MemberCount = cached.MemberCount
+	}
+	r.caches.AddGuild(cacheGuild)
+	if _, ok := r.caches.Guild(guild.ID); !ok {
+		return
+	}
+	r.track(entityKey{kind: kindGuild, id: guild.ID}, cachedBefore)
+
+	for i := range guild.Roles {
+		guild.Roles[i].GuildID = guild.ID
+	}
+	for i := range guild.Emojis {
+		guild.Emojis[i].GuildID = guild.ID
+	}
+	putGroupedList(r, kindRole, kindRoles, r.caches.RoleCache(), guild.ID, guild.Roles, cachedRoleID)
+	putGroupedList(r, kindEmoji, kindEmojis, r.caches.EmojiCache(), guild.ID, guild.Emojis, cachedEmojiID)
+	putGroupedList(r, kindSticker, kindStickers, r.caches.StickerCache(), guild.ID, guild.Stickers, cachedStickerID)
+}
+
+func (r *restImpl) GetGuild(guildID snowflake.ID, withCounts bool, opts ...rest.RequestOpt) (*discord.RestGuild, error) {
+	if guild, ok := r.caches.Guild(guildID); ok && !withCounts && r.fresh(entityKey{kind: kindGuild, id: guildID}) &&
+		r.complete(kindRoles, guildID, cache.FlagRoles) &&
+		r.complete(kindEmojis, guildID, cache.FlagEmojis) &&
+		r.complete(kindStickers, guildID, cache.FlagStickers) {
+		return &discord.RestGuild{
+			Guild:    guild.Guild,
+			Stickers: collectGroup(r.caches.StickerCache(), guildID),
+			Roles:    collectGroup(r.caches.RoleCache(), guildID),
+			Emojis:   collectGroup(r.caches.EmojiCache(), guildID),
+		}, nil
+	}
+
+	guild, err := r.Rest.GetGuild(guildID, withCounts, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r.putGuild(*guild)
+	return guild, nil
+}
+
+func (r *restImpl) UpdateGuild(guildID snowflake.ID, guildUpdate discord.GuildUpdate, opts ...rest.RequestOpt) (*discord.RestGuild, error) {
+	guild, err := r.Rest.UpdateGuild(guildID, guildUpdate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r.putGuild(*guild)
+	return guild, nil
+}
+
+func (r *restImpl) putChannel(channel discord.GuildChannel) {
+	key := entityKey{kind: kindChannel, id: channel.ID()}
+	_, cachedBefore := r.caches.Channel(channel.ID())
+	r.caches.AddChannel(channel)
+	if _, ok := r.caches.Channel(channel.ID()); !ok {
+		r.untrack(key)
+		r.untrack(entityKey{kind: kindGuildChannels, id: channel.GuildID()})
+		return
+	}
+	r.track(key, cachedBefore)
+}
+
+func (r *restImpl) GetChannel(channelID snowflake.ID, opts ...rest.RequestOpt) (discord.Channel, error) {
+	if channel, ok := r.caches.Channel(channelID); ok && r.fresh(entityKey{kind: kindChannel, id: channelID}) {
+		return channel, nil
+	}
+
+	channel, err := r.Rest.GetChannel(channelID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if guildChannel, ok := channel.(discord.GuildChannel); ok {
+		r.putChannel(guildChannel)
+	}
+	return channel, nil
+}
+
+func (r *restImpl) UpdateChannel(channelID snowflake.ID, channelUpdate discord.ChannelUpdate, opts ...rest.RequestOpt) (discord.Channel, error) {
+	channel, err := r.Rest.UpdateChannel(channelID, channelUpdate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if guildChannel, ok := channel.(discord.GuildChannel); ok {
+		r.putChannel(guildChannel)
+	}
+	return channel, nil
+}
+
+func (r *restImpl) DeleteChannel(channelID snowflake.ID, opts ...rest.RequestOpt) error {
+	if err := r.Rest.DeleteChannel(channelID, opts...); err != nil {
+		return err
+	}
+	r.caches.RemoveChannel(channelID)
+	r.untrack(entityKey{kind: kindChannel, id: channelID})
+	return nil
+}
+
+func (r *restImpl) GetGuildChannels(guildID snowflake.ID, opts ...rest.RequestOpt) ([]discord.GuildChannel, error) {
+	if r.complete(kindGuildChannels, guildID, cache.FlagChannels) {
+		var channels []discord.GuildChannel
+		for channel := range r.caches.ChannelsForGuild(guildID) {
+			// threads are not returned by Discord
+			if _, ok := channel.(discord.GuildThread); !ok {
+				channels = append(channels, channel)
+			}
+		}
+		return channels, nil
+	}
+
+	channels, err := r.Rest.GetGuildChannels(guildID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]snowflake.ID, len(channels))
+	complete := true
+	for i, channel := range channels {
+		ids[i] = channel.ID()
+		r.putChannel(channel)
+		if _, ok := r.caches.Channel(channel.ID()); !ok {
+			complete = false
+		}
+	}
+	r.caches.ChannelCache().RemoveIf(func(channel discord.GuildChannel) bool {
+		_, isThread := channel.(discord.GuildThread)
+		return channel.GuildID() == guildID && !isThread && !slices.Contains(ids, channel.ID())
+	})
+	key := entityKey{kind: kindGuildChannels, id: guildID}
+	if !complete {
+		r.untrack(key)
+		return channels, nil
+	}
+	r.track(key, false)
+	return channels, nil
+}
+
+func (r *restImpl) CreateGuildChannel(guildID snowflake.ID, guildChannelCreate discord.GuildChannelCreate, opts ...rest.RequestOpt) (discord.GuildChannel, error) {
+	channel, err := r.Rest.CreateGuildChannel(guildID, guildChannelCreate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r.putChannel(channel)
+	return channel, nil
+}
+
+func cachedRoleID(role discord.Role) snowflake.ID {
+	return role.ID
+}
+
+func (r *restImpl) GetRoles(guildID snowflake.ID, opts ...rest.RequestOpt) ([]discord.Role, error) {
+	if r.complete(kindRoles, guildID, cache.FlagRoles) {
+		return collectGroup(r.caches.RoleCache(), guildID), nil
+	}
+
+	roles, err := r.Rest.GetRoles(guildID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	putGroupedList(r, kindRole, kindRoles, r.caches.RoleCache(), guildID, roles, cachedRoleID)
+	return roles, nil
+}
+
+func (r *restImpl) GetRole(guildID snowflake.ID, roleID snowflake.ID, opts ...rest.RequestOpt) (*discord.Role, error) {
+	if role, ok := getGrouped(r, kindRole, r.caches.RoleCache(), guildID, roleID); ok {
+		return &role, nil
+	}
+
+	role, err := r.Rest.GetRole(guildID, roleID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	putGrouped(r, kindRole, r.caches.RoleCache(), guildID, roleID, *role)
+	return role, nil
+}
+
+func (r *restImpl) CreateRole(guildID snowflake.ID, createRole discord.RoleCreate, opts ...rest.RequestOpt) (*discord.Role, error) {
+	role, err := r.Rest.CreateRole(guildID, createRole, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !putGrouped(r, kindRole, r.caches.RoleCache(), guildID, role.ID, *role) {
+		r.untrack(entityKey{kind: kindRoles, id: guildID})
+	}
+	return role, nil
+}
+
+func (r *restImpl) UpdateRole(guildID snowflake.ID, roleID snowflake.ID, roleUpdate discord.RoleUpdate, opts ...rest.RequestOpt) (*discord.Role, error) {
+	role, err := r.Rest.UpdateRole(guildID, roleID, roleUpdate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !putGrouped(r, kindRole, r.caches.RoleCache(), guildID, roleID, *role) {
+		r.untrack(entityKey{kind: kindRoles, id: guildID})
+	}
+	return role, nil
+}
+
+func (r *restImpl) UpdateRolePositions(guildID snowflake.ID, rolePositionUpdates []discord.RolePositionUpdate, opts ...rest.RequestOpt) ([]discord.Role, error) {
+	roles, err := r.Rest.UpdateRolePositions(guildID, rolePositionUpdates, opts...)
+	if err != nil {
+		return nil, err
+	}
+	putGroupedList(r, kindRole, kindRoles, r.caches.RoleCache(), guildID, roles, cachedRoleID)
+	return roles, nil
+}
+
+func (r *restImpl) DeleteRole(guildID snowflake.ID, roleID snowflake.ID, opts ...rest.RequestOpt) error {
+	if err := r.Rest.DeleteRole(guildID, roleID, opts...); err != nil {
+		return err
+	}
+	removeGrouped(r, kindRole, r.caches.RoleCache(), guildID, roleID)
+	return nil
+}
+
+func (r *restImpl) putMember(member discord.Member) {
+	putGrouped(r, kindMember, r.caches.MemberCache(), member.GuildID, member.User.ID, member)
+}
+
+func (r *restImpl) GetMember(guildID snowflake.ID, userID snowflake.ID, opts ...rest.RequestOpt) (*discord.Member, error) {
+	if member, ok := getGrouped(r, kindMember, r.caches.MemberCache(), guildID, userID); ok {
+		return &member, nil
+	}
+
+	member, err := r.Rest.GetMember(guildID, userID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r.putMember(*member)
+	return member, nil
+}
+
+func (r *restImpl) AddMember(guildID snowflake.ID, userID snowflake.ID, memberAdd discord.MemberAdd, opts ...rest.RequestOpt) (*discord.Member, error) {
+	member, err := r.Rest.AddMember(guildID, userID, memberAdd, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// Discord returns no member if the user is already in the guild
+	if member != nil {
+		r.putMember(*member)
+	}
+	return member, nil
+}
+
+func (r *restImpl) UpdateMember(guildID snowflake.ID, userID snowflake.ID, memberUpdate discord.MemberUpdate, opts ...rest.RequestOpt) (*discord.Member, error) {
+	member, err := r.Rest.UpdateMember(guildID, userID, memberUpdate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r.putMember(*member)
+	return member, nil
+}
+
+func (r *restImpl) UpdateCurrentMember(guildID snowflake.ID, memberUpdate discord.CurrentMemberUpdate, opts ...rest.RequestOpt) (*discord.Member, error) {
+	member, err := r.Rest.UpdateCurrentMember(guildID, memberUpdate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	member.GuildID = guildID
+	r.putMember(*member)
+	return member, nil
+}
+
+func (r *restImpl) RemoveMember(guildID snowflake.ID, userID snowflake.ID, opts ...rest.RequestOpt) error {
+	if err := r.Rest.RemoveMember(guildID, userID, opts...); err != nil {
+		return err
+	}
+	removeGrouped(r, kindMember, r.caches.MemberCache(), guildID, userID)
+	return nil
+}
+
+// updateMemberRoles applies a role change to a fetched member. Members received from the gateway are updated by the gateway.
+func (r *restImpl) updateMemberRoles(guildID snowflake.ID, userID snowflake.ID, update func(roleIDs []snowflake.ID) []snowflake.ID) {
+	if !r.tracked(entityKey{kind: kindMember, groupID: guildID, id: userID}) {
+		return
+	}
+	member, ok := r.caches.Member(guildID, userID)
+	if !ok {
+		return
+	}
+	member.RoleIDs = update(slices.Clone(member.RoleIDs))
+	r.putMember(member)
+}
+
+func (r *restImpl) AddMemberRole(guildID snowflake.ID, userID snowflake.ID, roleID snowflake.ID, opts ...rest.RequestOpt) error {
+	if err := r.Rest.AddMemberRole(guildID, userID, roleID, opts...); err != nil {
+		return err
+	}
+	r.updateMemberRoles(guildID, userID, func(roleIDs []snowflake.ID) []snowflake.ID {
+		if slices.Contains(roleIDs, roleID) {
+			return roleIDs
+		}
+		return append(roleIDs, roleID)
+	})
+	return nil
+}
+
+func (r *restImpl) RemoveMemberRole(guildID snowflake.ID, userID snowflake.ID, roleID snowflake.ID, opts ...rest.RequestOpt) error {
+	if err := r.Rest.RemoveMemberRole(guildID, userID, roleID, opts...); err != nil {
+		return err
+	}
+	r.updateMemberRoles(guildID, userID, func(roleIDs []snowflake.ID) []snowflake.ID {
+		return slices.DeleteFunc(roleIDs, func(id snowflake.ID) bool {
+			return id == roleID
+		})
+	})
+	return nil
+}
+
+func cachedEmojiID(emoji discord.Emoji) snowflake.ID {
+	return emoji.ID
+}
+
+func (r *restImpl) GetEmojis(guildID snowflake.ID, opts ...rest.RequestOpt) ([]discord.Emoji, error) {
+	if r.complete(kindEmojis, guildID, cache.FlagEmojis) {
+		return collectGroup(r.caches.EmojiCache(), guildID), nil
+	}
+
+	emojis, err := r.Rest.GetEmojis(guildID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	putGroupedList(r, kindEmoji, kindEmojis, r.caches.EmojiCache(), guildID, emojis, cachedEmojiID)
+	return emojis, nil
+}
+
+func (r *restImpl) GetEmoji(guildID snowflake.ID, emojiID snowflake.ID, opts ...rest.RequestOpt) (*discord.Emoji, error) {
+	if emoji, ok := getGrouped(r, kindEmoji, r.caches.EmojiCache(), guildID, emojiID); ok {
+		return &emoji, nil
+	}
+
+	emoji, err := r.Rest.GetEmoji(guildID, emojiID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	putGrouped(r, kindEmoji, r.caches.EmojiCache(), guildID, emojiID, *emoji)
+	return emoji, nil
+}
+
+func (r *restImpl) CreateEmoji(guildID snowflake.ID, emojiCreate discord.EmojiCreate, opts ...rest.RequestOpt) (*discord.Emoji, error) {
+	emoji, err := r.Rest.CreateEmoji(guildID, emojiCreate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !putGrouped(r, kindEmoji, r.caches.EmojiCache(), guildID, emoji.ID, *emoji) {
+		r.untrack(entityKey{kind: kindEmojis, id: guildID})
+	}
+	return emoji, nil
+}
+
+func (r *restImpl) UpdateEmoji(guildID snowflake.ID, emojiID snowflake.ID, emojiUpdate discord.EmojiUpdate, opts ...rest.RequestOpt) (*discord.Emoji, error) {
+	emoji, err := r.Rest.UpdateEmoji(guildID, emojiID, emojiUpdate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !putGrouped(r, kindEmoji, r.caches.EmojiCache(), guildID, emojiID, *emoji) {
+		r.untrack(entityKey{kind: kindEmojis, id: guildID})
+	}
+	return emoji, nil
+}
+
+func (r *restImpl) DeleteEmoji(guildID snowflake.ID, emojiID snowflake.ID, opts ...rest.RequestOpt) error {
+	if err := r.Rest.DeleteEmoji(guildID, emojiID, opts...); err != nil {
+		return err
+	}
+	removeGrouped(r, kindEmoji, r.caches.EmojiCache(), guildID, emojiID)
+	return nil
+}
+
+func cachedStickerID(sticker discord.Sticker) snowflake.ID {
+	return sticker.ID
+}
+
+func (r *restImpl) GetStickers(guildID snowflake.ID, opts ...rest.RequestOpt) ([]discord.Sticker, error) {
+	if r.complete(kindStickers, guildID, cache.FlagStickers) {
+		return collectGroup(r.caches.StickerCache(), guildID), nil
+	}
+
+	stickers, err := r.Rest.GetStickers(guildID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	putGroupedList(r, kindSticker, kindStickers, r.caches.StickerCache(), guildID, stickers, cachedStickerID)
+	return stickers, nil
+}
+
+func (r *restImpl) GetSticker(stickerID snowflake.ID, opts ...rest.RequestOpt) (*discord.Sticker, error) {
+	for guildID, sticker := range r.caches.StickerCache().All() {
+		if sticker.ID == stickerID && r.fresh(entityKey{kind: kindSticker, groupID: guildID, id: stickerID}) {
+			return &sticker, nil
+		}
+	}
+
+	sticker, err := r.Rest.GetSticker(stickerID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// standard stickers don't belong to a guild
+	if sticker.GuildID != nil {
+		putGrouped(r, kindSticker, r.caches.StickerCache(), *sticker.GuildID, stickerID, *sticker)
+	}
+	return sticker, nil
+}
+
+func (r *restImpl) CreateSticker(guildID snowflake.ID, createSticker discord.StickerCreate, opts ...rest.RequestOpt) (*discord.Sticker, error) {
+	sticker, err := r.Rest.CreateSticker(guildID, createSticker, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !putGrouped(r, kindSticker, r.caches.StickerCache(), guildID, sticker.ID, *sticker) {
+		r.untrack(entityKey{kind: kindStickers, id: guildID})
+	}
+	return sticker, nil
+}
+
+func (r *restImpl) UpdateSticker(guildID snowflake.ID, stickerID snowflake.ID, stickerUpdate discord.StickerUpdate, opts ...rest.RequestOpt) (*discord.Sticker, error) {
+	sticker, err := r.Rest.UpdateSticker(guildID, stickerID, stickerUpdate, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !putGrouped(r, kindSticker, r.caches.StickerCache(), guildID, stickerID, *sticker) {
+		r.untrack(entityKey{kind: kindStickers, id: guildID})
+	}
+	return sticker, nil
+}
+
+func (r *restImpl) DeleteSticker(guildID snowflake.ID, stickerID snowflake.ID, opts ...rest.RequestOpt) error {
+	if err := r.Rest.DeleteSticker(guildID, stickerID, opts...); err != nil {
+		return err
+	}
+	removeGrouped(r, kindSticker, r.caches.StickerCache(), guildID, stickerID)
+	return nil
+}
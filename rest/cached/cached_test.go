@@ -0,0 +1,158 @@
+package cached
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func newCachedTestRest(t *testing.T, caches cache.Caches, opts ...ConfigOpt) (rest.Rest, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/guilds/1/members/2":
+			_, _ = w.Write([]byte(`{"user":{"id":"2","username":"test"},"roles":[]}`))
+		case "/guilds/1/roles":
+			_, _ = w.Write([]byte(`[{"id":"1","name":"@everyone"},{"id":"3","name":"test"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":10004,"message":"Unknown Guild"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := rest.NewClient("token", rest.WithURL(server.URL), rest.WithRateLimiter(rest.NewNoopRateLimiter()))
+	return New(rest.New(client), caches, opts...), &requests
+}
+
+func TestCached_Member(t *testing.T) {
+	caches := cache.New(cache.WithCaches(cache.FlagsAll))
+	client, requests := newCachedTestRest(t, caches, WithMaxStaleness(50*time.Millisecond))
+
+	for range 2 {
+		member, err := client.GetMember(1, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if member.User.ID != 2 || member.GuildID != 1 {
+			t.Fatalf("unexpected member: %+v", member)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+	if _, ok := caches.Member(1, 2); !ok {
+		t.Errorf("expected the member to be cached")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if _, err := client.GetMember(1, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected the stale member to be fetched again, got %d requests", n)
+	}
+
+	// members received from the gateway don't become stale
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 4}})
+	time.Sleep(50 * time.Millisecond)
+	if _, err := client.GetMember(1, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected the gateway member to be served from the cache, got %d requests", n)
+	}
+}
+
+func TestCached_Policy(t *testing.T) {
+	caches := cache.New(cache.WithCaches(cache.FlagsAll), cache.WithMemberCachePolicy(cache.PolicyNone[discord.Member]))
+	client, requests := newCachedTestRest(t, caches)
+
+	for range 2 {
+		if _, err := client.GetMember(1, 2); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected members rejected by the policy to be fetched every time, got %d requests", n)
+	}
+}
+
+func TestCached_Roles(t *testing.T) {
+	caches := cache.New(cache.WithCaches(cache.FlagsAll))
+	client, requests := newCachedTestRest(t, caches)
+
+	// only a single role is known, so the list has to be fetched
+	caches.AddRole(discord.Role{GuildID: 1, ID: 1, Name: "@everyone"})
+	for range 2 {
+		roles, err := client.GetRoles(1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(roles) != 2 {
+			t.Fatalf("expected 2 roles, got %+v", roles)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+
+	// guilds received from the gateway come with all roles
+	caches.AddGuild(discord.CacheGuild{Guild: discord.Guild{ID: 5}})
+	caches.AddRole(discord.Role{GuildID: 5, ID: 5, Name: "@everyone"})
+	roles, err := client.GetRoles(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(roles) != 1 || requests.Load() != 1 {
+		t.Errorf("expected the roles to be served from the cache, got %+v", roles)
+	}
+}
+
+func TestCached_Sweep(t *testing.T) {
+	caches := cache.New(cache.WithCaches(cache.FlagsAll))
+	client, _ := newCachedTestRest(t, caches, WithMaxStaleness(50*time.Millisecond))
+	impl := client.(*restImpl)
+	sweep := func() {
+		impl.mu.Lock()
+		defer impl.mu.Unlock()
+		impl.lastSweep = time.Time{}
+		impl.sweep()
+	}
+
+	if _, err := client.GetMember(1, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetRoles(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// entities evicted from the caches are no longer tracked
+	caches.RemoveMember(1, 2)
+	sweep()
+	if impl.tracked(entityKey{kind: kindMember, groupID: 1, id: 2}) {
+		t.Error("expected the evicted member not to be tracked")
+	}
+	if !impl.tracked(entityKey{kind: kindRole, groupID: 1, id: 3}) || !impl.tracked(entityKey{kind: kindRoles, id: 1}) {
+		t.Error("expected the cached roles to be tracked")
+	}
+
+	// stale entities are removed from the caches together with their entry
+	time.Sleep(50 * time.Millisecond)
+	sweep()
+	if n := len(impl.fetched); n != 0 {
+		t.Errorf("expected no tracked entities, got %d", n)
+	}
+	if _, ok := caches.Role(1, 3); ok {
+		t.Error("expected the stale role to be removed from the cache")
+	}
+}
@@ -0,0 +1,31 @@
+package cached
+
+import (
+	"time"
+)
+
+func defaultConfig() config {
+	return config{}
+}
+
+type config struct {
+	MaxStaleness time.Duration
+}
+
+// ConfigOpt can be used to supply optional parameters to New
+type ConfigOpt func(config *config)
+
+func (c *config) apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithMaxStaleness sets how long entities fetched by the cached rest.Rest are served from the cache before they are fetched again.
+// Entities kept up to date by the gateway are always served from the cache. By default, fetched entities never expire.
+// Stale entities are removed from the caches within a minute after they expire.
+func WithMaxStaleness(maxStaleness time.Duration) ConfigOpt {
+	return func(config *config) {
+		config.MaxStaleness = maxStaleness
+	}
+}
@@ -0,0 +1,8 @@
+// Package cached provides a rest.Rest which serves entities from cache.Caches before fetching them from Discord.
+//
+// It lives outside the rest package, so rest does not depend on the cache package:
+//
+//	client := cached.New(rest.New(rest.NewClient(token)), caches, cached.WithMaxStaleness(time.Minute))
+//
+// Bots created with bot.New can use bot.WithCachedRest instead.
+package cached
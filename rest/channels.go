@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"context"
+	"iter"
 	"time"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -30,10 +32,19 @@ type Channels interface {
 	GetMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 	GetMessages(channelID snowflake.ID, around snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Message, error)
 	GetMessagesPage(channelID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Message]
+	// MessagesIter returns an iterator over the messages of a channel starting at startID in the given direction. It stops after limit messages, or never if limit is 0.
+	MessagesIter(ctx context.Context, channelID snowflake.ID, startID snowflake.ID, direction PageDirection, limit int, opts ...RequestOpt) iter.Seq2[discord.Message, error]
 	CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (*discord.Message, error)
+	// ForwardMessage forwards the message with the given ID from the source channel to the given channel.
+	// The forwarded content is available in discord.Message.MessageSnapshots of the returned message.
+	ForwardMessage(channelID snowflake.ID, sourceChannelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 	UpdateMessage(channelID snowflake.ID, messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...RequestOpt) (*discord.Message, error)
 	DeleteMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) error
 	BulkDeleteMessages(channelID snowflake.ID, messageIDs []snowflake.ID, opts ...RequestOpt) error
+	// Purge returns an iterator which deletes the messages of a channel matching the given PurgeOptions, newest first, and yields every deleted PurgeBatch.
+	// Messages are bulk deleted in batches of up to 100, messages older than BulkDeleteMaxAge are deleted one by one.
+	// A batch which failed to delete is yielded with its error. Breaking out of the loop stops the purge.
+	Purge(ctx context.Context, channelID snowflake.ID, options PurgeOptions, opts ...RequestOpt) iter.Seq2[PurgeBatch, error]
 	CrosspostMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 
 	GetReactions(channelID snowflake.ID, messageID snowflake.ID, emoji string, reactionType discord.MessageReactionType, after int, limit int, opts ...RequestOpt) ([]discord.User, error)
@@ -45,6 +56,8 @@ type Channels interface {
 
 	GetChannelPins(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (*discord.ChannelPins, error)
 	GetChannelPinsPage(channelID snowflake.ID, start time.Time, limit int, opts ...RequestOpt) ChannelPinsPage
+	// ChannelPinsIter returns an iterator over the pinned messages of a channel pinned before start, newest first. It stops after limit pins, or never if limit is 0.
+	ChannelPinsIter(ctx context.Context, channelID snowflake.ID, start time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.MessagePin, error]
 	PinMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) error
 	UnpinMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) error
 
@@ -52,6 +65,8 @@ type Channels interface {
 
 	GetPollAnswerVotes(channelID snowflake.ID, messageID snowflake.ID, answerID int, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.User, error)
 	GetPollAnswerVotesPage(channelID snowflake.ID, messageID snowflake.ID, answerID int, startID snowflake.ID, limit int, opts ...RequestOpt) PollAnswerVotesPage
+	// PollAnswerVotesIter returns an iterator over the users who voted for a poll answer with an ID after startID. It stops after limit users, or never if limit is 0.
+	PollAnswerVotesIter(ctx context.Context, channelID snowflake.ID, messageID snowflake.ID, answerID int, startID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.User, error]
 	ExpirePoll(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 
 	SetVoiceChannelStatus(channelID snowflake.ID, update discord.VoiceChannelStatusUpdate, opts ...RequestOpt) error
@@ -148,6 +163,16 @@ func (s *channelImpl) GetMessagesPage(channelID snowflake.ID, startID snowflake.
 	}
 }
 
+func (s *channelImpl) MessagesIter(ctx context.Context, channelID snowflake.ID, startID snowflake.ID, direction PageDirection, limit int, opts ...RequestOpt) iter.Seq2[discord.Message, error] {
+	opts = withIterCtx(ctx, opts)
+	startID = forwardStartID(direction, startID)
+	return iterPages(ctx, direction, startID, limit, func(before snowflake.ID, after snowflake.ID) ([]discord.Message, error) {
+		return s.GetMessages(channelID, 0, before, after, 100, opts...)
+	}, func(msg discord.Message) snowflake.ID {
+		return msg.ID
+	})
+}
+
 func (s *channelImpl) CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (message *discord.Message, err error) {
 	if messageCreate.AllowedMentions == nil {
 		messageCreate.AllowedMentions = &s.defaultAllowedMentions
@@ -160,6 +185,10 @@ func (s *channelImpl) CreateMessage(channelID snowflake.ID, messageCreate discor
 	return
 }
 
+func (s *channelImpl) ForwardMessage(channelID snowflake.ID, sourceChannelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error) {
+	return s.CreateMessage(channelID, discord.MessageCreate{}.WithMessageForward(sourceChannelID, messageID), opts...)
+}
+
 func (s *channelImpl) UpdateMessage(channelID snowflake.ID, messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...RequestOpt) (message *discord.Message, err error) {
 	if messageUpdate.AllowedMentions == nil && (messageUpdate.Content != nil || (messageUpdate.Flags != nil && messageUpdate.Flags.Has(discord.MessageFlagIsComponentsV2))) {
 		messageUpdate.AllowedMentions = &s.defaultAllowedMentions
@@ -244,6 +273,19 @@ func (s *channelImpl) GetChannelPinsPage(channelID snowflake.ID, start time.Time
 	}
 }
 
+func (s *channelImpl) ChannelPinsIter(ctx context.Context, channelID snowflake.ID, start time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.MessagePin, error] {
+	opts = withIterCtx(ctx, opts)
+	return iterTimePages(ctx, start, limit, func(before time.Time) ([]discord.MessagePin, bool, error) {
+		pins, err := s.GetChannelPins(channelID, before, 50, opts...)
+		if err != nil {
+			return nil, false, err
+		}
+		return pins.Items, pins.HasMore, nil
+	}, func(pin discord.MessagePin) time.Time {
+		return pin.PinnedAt
+	})
+}
+
 func (s *channelImpl) PinMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) error {
 	return s.client.Do(PinMessage.Compile(nil, channelID, messageID), nil, nil, opts...)
 }
@@ -282,6 +324,15 @@ func (s *channelImpl) GetPollAnswerVotesPage(channelID snowflake.ID, messageID s
 	}
 }
 
+func (s *channelImpl) PollAnswerVotesIter(ctx context.Context, channelID snowflake.ID, messageID snowflake.ID, answerID int, startID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.User, error] {
+	opts = withIterCtx(ctx, opts)
+	return iterPages(ctx, PageDirectionForward, startID, limit, func(_ snowflake.ID, after snowflake.ID) ([]discord.User, error) {
+		return s.GetPollAnswerVotes(channelID, messageID, answerID, after, 100, opts...)
+	}, func(user discord.User) snowflake.ID {
+		return user.ID
+	})
+}
+
 func (s *channelImpl) ExpirePoll(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (message *discord.Message, err error) {
 	err = s.client.Do(ExpirePoll.Compile(nil, channelID, messageID), nil, &message, opts...)
 	return
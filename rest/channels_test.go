@@ -0,0 +1,66 @@
+package rest_test
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/resttest"
+)
+
+func TestMessagesIter_ForwardFromStart(t *testing.T) {
+	fake := resttest.NewServer(resttest.WithRateLimit(1000, time.Second))
+	defer fake.Close()
+	client := rest.New(rest.NewClient("token", rest.WithURL(fake.URL)))
+
+	guildID := fake.CreateGuild("test")
+	channelID := fake.CreateChannel(guildID, discord.ChannelTypeGuildText, "general")
+	for i := range 101 {
+		if _, err := client.CreateMessage(channelID, discord.MessageCreate{Content: strconv.Itoa(i)}); err != nil {
+			t.Fatalf("unexpected error creating message: %v", err)
+		}
+	}
+
+	var contents []string
+	for msg, err := range client.MessagesIter(context.Background(), channelID, 0, rest.PageDirectionForward, 0) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		contents = append(contents, msg.Content)
+	}
+	if len(contents) != 101 {
+		t.Fatalf("expected all 101 messages, got %d", len(contents))
+	}
+	for i, content := range contents {
+		if content != strconv.Itoa(i) {
+			t.Fatalf("expected messages oldest first, got %q at position %d", content, i)
+		}
+	}
+
+	// a limit smaller than a page must return the oldest messages, not the newest of the first page
+	contents = nil
+	for msg, err := range client.MessagesIter(context.Background(), channelID, 0, rest.PageDirectionForward, 5) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		contents = append(contents, msg.Content)
+	}
+	if expected := []string{"0", "1", "2", "3", "4"}; !slices.Equal(contents, expected) {
+		t.Errorf("expected %v, got %v", expected, contents)
+	}
+
+	contents = nil
+	for msg, err := range client.MessagesIter(context.Background(), channelID, 0, rest.PageDirectionBackward, 3) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		contents = append(contents, msg.Content)
+	}
+	if expected := []string{"100", "99", "98"}; !slices.Equal(contents, expected) {
+		t.Errorf("expected %v, got %v", expected, contents)
+	}
+}
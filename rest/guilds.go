@@ -2,6 +2,7 @@ package rest
 
 import (
 	"context"
+	"iter"
 	"time"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -23,6 +24,13 @@ type Guilds interface {
 
 	GetGuildVanityURL(guildID snowflake.ID, opts ...RequestOpt) (*discord.PartialInvite, error)
 
+	GetGuildWidgetSettings(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildWidgetSettings, error)
+	UpdateGuildWidget(guildID snowflake.ID, widgetUpdate discord.GuildWidgetSettingsUpdate, opts ...RequestOpt) (*discord.GuildWidgetSettings, error)
+	// GetGuildWidget returns the public widget of a guild. This does not require authentication, but the widget has to be enabled.
+	GetGuildWidget(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildWidget, error)
+	// GetGuildWidgetImage returns the PNG image of the widget of a guild in the given style. An empty style uses discord.GuildWidgetStyleShield.
+	GetGuildWidgetImage(guildID snowflake.ID, style discord.GuildWidgetStyle, opts ...RequestOpt) ([]byte, error)
+
 	CreateGuildChannel(guildID snowflake.ID, guildChannelCreate discord.GuildChannelCreate, opts ...RequestOpt) (discord.GuildChannel, error)
 	GetGuildChannels(guildID snowflake.ID, opts ...RequestOpt) ([]discord.GuildChannel, error)
 	UpdateChannelPositions(guildID snowflake.ID, guildChannelPositionUpdates []discord.GuildChannelPositionUpdate, opts ...RequestOpt) error
@@ -37,6 +45,8 @@ type Guilds interface {
 
 	GetBans(guildID snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Ban, error)
 	GetBansPage(guildID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Ban]
+	// BansIter returns an iterator over the bans of a guild starting at the user startID in the given direction. It stops after limit bans, or never if limit is 0.
+	BansIter(ctx context.Context, guildID snowflake.ID, startID snowflake.ID, direction PageDirection, limit int, opts ...RequestOpt) iter.Seq2[discord.Ban, error]
 	GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.Ban, error)
 	AddBan(guildID snowflake.ID, userID snowflake.ID, deleteMessageDuration time.Duration, opts ...RequestOpt) error
 	DeleteBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -54,6 +64,8 @@ type Guilds interface {
 
 	GetAuditLog(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) (*discord.AuditLog, error)
 	GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, startID snowflake.ID, limit int, opts ...RequestOpt) AuditLogPage
+	// AuditLogIter returns an iterator over the audit log entries of a guild starting at startID in the given direction. It stops after limit entries, or never if limit is 0.
+	AuditLogIter(ctx context.Context, guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, startID snowflake.ID, direction PageDirection, limit int, opts ...RequestOpt) iter.Seq2[discord.AuditLogEntry, error]
 
 	GetGuildWelcomeScreen(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildWelcomeScreen, error)
 	UpdateGuildWelcomeScreen(guildID snowflake.ID, screenUpdate discord.GuildWelcomeScreenUpdate, opts ...RequestOpt) (*discord.GuildWelcomeScreen, error)
@@ -93,6 +105,30 @@ func (s *guildImpl) GetGuildVanityURL(guildID snowflake.ID, opts ...RequestOpt)
 	return
 }
 
+func (s *guildImpl) GetGuildWidgetSettings(guildID snowflake.ID, opts ...RequestOpt) (settings *discord.GuildWidgetSettings, err error) {
+	err = s.client.Do(GetGuildWidgetSettings.Compile(nil, guildID), nil, &settings, opts...)
+	return
+}
+
+func (s *guildImpl) UpdateGuildWidget(guildID snowflake.ID, widgetUpdate discord.GuildWidgetSettingsUpdate, opts ...RequestOpt) (settings *discord.GuildWidgetSettings, err error) {
+	err = s.client.Do(UpdateGuildWidget.Compile(nil, guildID), widgetUpdate, &settings, opts...)
+	return
+}
+
+func (s *guildImpl) GetGuildWidget(guildID snowflake.ID, opts ...RequestOpt) (widget *discord.GuildWidget, err error) {
+	err = s.client.Do(GetGuildWidget.Compile(nil, guildID), nil, &widget, opts...)
+	return
+}
+
+func (s *guildImpl) GetGuildWidgetImage(guildID snowflake.ID, style discord.GuildWidgetStyle, opts ...RequestOpt) (image []byte, err error) {
+	values := discord.QueryValues{}
+	if style != "" {
+		values["style"] = style
+	}
+	err = s.client.Do(GetGuildWidgetImage.Compile(values, guildID), nil, &image, opts...)
+	return
+}
+
 func (s *guildImpl) CreateGuildChannel(guildID snowflake.ID, guildChannelCreate discord.GuildChannelCreate, opts ...RequestOpt) (guildChannel discord.GuildChannel, err error) {
 	var ch discord.UnmarshalChannel
 	err = s.client.Do(CreateGuildChannel.Compile(nil, guildID), guildChannelCreate, &ch, opts...)
@@ -198,6 +234,15 @@ func (s *guildImpl) GetBansPage(guildID snowflake.ID, startID snowflake.ID, limi
 	}
 }
 
+func (s *guildImpl) BansIter(ctx context.Context, guildID snowflake.ID, startID snowflake.ID, direction PageDirection, limit int, opts ...RequestOpt) iter.Seq2[discord.Ban, error] {
+	opts = withIterCtx(ctx, opts)
+	return iterPages(ctx, direction, startID, limit, func(before snowflake.ID, after snowflake.ID) ([]discord.Ban, error) {
+		return s.GetBans(guildID, before, after, 1000, opts...)
+	}, func(ban discord.Ban) snowflake.ID {
+		return ban.User.ID
+	})
+}
+
 func (s *guildImpl) GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (ban *discord.Ban, err error) {
 	err = s.client.Do(GetBan.Compile(nil, guildID, userID), nil, &ban, opts...)
 	return
@@ -293,6 +338,20 @@ func (s *guildImpl) GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, a
 	}
 }
 
+func (s *guildImpl) AuditLogIter(ctx context.Context, guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, startID snowflake.ID, direction PageDirection, limit int, opts ...RequestOpt) iter.Seq2[discord.AuditLogEntry, error] {
+	opts = withIterCtx(ctx, opts)
+	startID = forwardStartID(direction, startID)
+	return iterPages(ctx, direction, startID, limit, func(before snowflake.ID, after snowflake.ID) ([]discord.AuditLogEntry, error) {
+		auditLog, err := s.GetAuditLog(guildID, userID, actionType, before, after, 100, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return auditLog.AuditLogEntries, nil
+	}, func(entry discord.AuditLogEntry) snowflake.ID {
+		return entry.ID
+	})
+}
+
 func (s *guildImpl) GetGuildWelcomeScreen(guildID snowflake.ID, opts ...RequestOpt) (welcomeScreen *discord.GuildWelcomeScreen, err error) {
 	err = s.client.Do(GetGuildWelcomeScreen.Compile(nil, guildID), nil, &welcomeScreen, opts...)
 	return
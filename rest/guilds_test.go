@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestGuilds_GetGuildWidgetImage(t *testing.T) {
+	image := []byte("\x89PNG\r\n\x1a\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/1/widget.png" || r.URL.Query().Get("style") != "banner2" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("expected no authorization, got %q", auth)
+		}
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(image)
+	}))
+	defer server.Close()
+
+	guilds := NewGuilds(NewClient("token", WithURL(server.URL), WithRateLimiter(NewNoopRateLimiter())))
+	got, err := guilds.GetGuildWidgetImage(1, discord.GuildWidgetStyleBanner2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, image) {
+		t.Errorf("expected %q, got %q", image, got)
+	}
+}
@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var _ Lobbies = (*lobbyImpl)(nil)
+
+func NewLobbies(client Client) Lobbies {
+	return &lobbyImpl{client: client}
+}
+
+type Lobbies interface {
+	CreateLobby(lobbyCreate discord.LobbyCreate, opts ...RequestOpt) (*discord.Lobby, error)
+	GetLobby(lobbyID snowflake.ID, opts ...RequestOpt) (*discord.Lobby, error)
+	UpdateLobby(lobbyID snowflake.ID, lobbyUpdate discord.LobbyUpdate, opts ...RequestOpt) (*discord.Lobby, error)
+	DeleteLobby(lobbyID snowflake.ID, opts ...RequestOpt) error
+
+	// AddLobbyMember adds a user to a lobby or updates their member if they are already in it.
+	AddLobbyMember(lobbyID snowflake.ID, userID snowflake.ID, memberUpdate discord.LobbyMemberUpdate, opts ...RequestOpt) (*discord.LobbyMember, error)
+	RemoveLobbyMember(lobbyID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
+	// LeaveLobby removes the current user from a lobby. This requires a Bearer token, see WithToken.
+	LeaveLobby(lobbyID snowflake.ID, opts ...RequestOpt) error
+
+	// LinkLobbyChannel links a lobby to a channel. This requires a Bearer token of a member with discord.LobbyMemberFlagCanLinkLobby, see WithToken.
+	LinkLobbyChannel(lobbyID snowflake.ID, channelID snowflake.ID, opts ...RequestOpt) (*discord.Lobby, error)
+	// UnlinkLobbyChannel unlinks a lobby from its channel. This requires a Bearer token of a member with discord.LobbyMemberFlagCanLinkLobby, see WithToken.
+	UnlinkLobbyChannel(lobbyID snowflake.ID, opts ...RequestOpt) (*discord.Lobby, error)
+}
+
+type lobbyImpl struct {
+	client Client
+}
+
+func (s *lobbyImpl) CreateLobby(lobbyCreate discord.LobbyCreate, opts ...RequestOpt) (lobby *discord.Lobby, err error) {
+	err = s.client.Do(CreateLobby.Compile(nil), lobbyCreate, &lobby, opts...)
+	return
+}
+
+func (s *lobbyImpl) GetLobby(lobbyID snowflake.ID, opts ...RequestOpt) (lobby *discord.Lobby, err error) {
+	err = s.client.Do(GetLobby.Compile(nil, lobbyID), nil, &lobby, opts...)
+	return
+}
+
+func (s *lobbyImpl) UpdateLobby(lobbyID snowflake.ID, lobbyUpdate discord.LobbyUpdate, opts ...RequestOpt) (lobby *discord.Lobby, err error) {
+	err = s.client.Do(UpdateLobby.Compile(nil, lobbyID), lobbyUpdate, &lobby, opts...)
+	return
+}
+
+func (s *lobbyImpl) DeleteLobby(lobbyID snowflake.ID, opts ...RequestOpt) error {
+	return s.client.Do(DeleteLobby.Compile(nil, lobbyID), nil, nil, opts...)
+}
+
+func (s *lobbyImpl) AddLobbyMember(lobbyID snowflake.ID, userID snowflake.ID, memberUpdate discord.LobbyMemberUpdate, opts ...RequestOpt) (member *discord.LobbyMember, err error) {
+	err = s.client.Do(AddLobbyMember.Compile(nil, lobbyID, userID), memberUpdate, &member, opts...)
+	return
+}
+
+func (s *lobbyImpl) RemoveLobbyMember(lobbyID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error {
+	return s.client.Do(RemoveLobbyMember.Compile(nil, lobbyID, userID), nil, nil, opts...)
+}
+
+func (s *lobbyImpl) LeaveLobby(lobbyID snowflake.ID, opts ...RequestOpt) error {
+	return s.client.Do(LeaveLobby.Compile(nil, lobbyID), nil, nil, opts...)
+}
+
+func (s *lobbyImpl) LinkLobbyChannel(lobbyID snowflake.ID, channelID snowflake.ID, opts ...RequestOpt) (lobby *discord.Lobby, err error) {
+	err = s.client.Do(LinkLobbyChannel.Compile(nil, lobbyID), discord.LobbyChannelLink{ChannelID: &channelID}, &lobby, opts...)
+	return
+}
+
+func (s *lobbyImpl) UnlinkLobbyChannel(lobbyID snowflake.ID, opts ...RequestOpt) (lobby *discord.Lobby, err error) {
+	err = s.client.Do(LinkLobbyChannel.Compile(nil, lobbyID), discord.LobbyChannelLink{}, &lobby, opts...)
+	return
+}
@@ -0,0 +1,146 @@
+package rest_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/resttest"
+)
+
+func TestLobbies(t *testing.T) {
+	fake := resttest.NewServer()
+	defer fake.Close()
+	client := rest.New(rest.NewClient("token", rest.WithURL(fake.URL)))
+	fake.AddUser(discord.User{ID: 10, Username: "first"})
+	fake.AddUser(discord.User{ID: 11, Username: "second"})
+
+	lobby, err := client.CreateLobby(discord.LobbyCreate{
+		Metadata: map[string]string{"mode": "ranked"},
+		Members:  []discord.LobbyMemberAdd{{ID: 10, Flags: discord.LobbyMemberFlagCanLinkLobby}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating lobby: %v", err)
+	}
+	if lobby.ApplicationID != fake.ApplicationID() || lobby.Metadata["mode"] != "ranked" || len(lobby.Members) != 1 || lobby.Members[0].ID != 10 {
+		t.Errorf("unexpected created lobby: %+v", lobby)
+	}
+
+	member, err := client.AddLobbyMember(lobby.ID, 11, discord.LobbyMemberUpdate{Metadata: map[string]string{"team": "red"}})
+	if err != nil {
+		t.Fatalf("unexpected error adding member: %v", err)
+	}
+	if member.ID != 11 || member.Metadata["team"] != "red" {
+		t.Errorf("unexpected added member: %+v", member)
+	}
+	if _, err = client.AddLobbyMember(lobby.ID, 12, discord.LobbyMemberUpdate{}); err == nil {
+		t.Error("expected an error adding an unknown user")
+	}
+
+	if err = client.RemoveLobbyMember(lobby.ID, 10); err != nil {
+		t.Fatalf("unexpected error removing member: %v", err)
+	}
+	got, err := client.GetLobby(lobby.ID)
+	if err != nil {
+		t.Fatalf("unexpected error getting lobby: %v", err)
+	}
+	if len(got.Members) != 1 || got.Members[0].ID != 11 {
+		t.Errorf("expected only the added member, got %+v", got.Members)
+	}
+
+	metadata := map[string]string{"mode": "casual"}
+	updated, err := client.UpdateLobby(lobby.ID, discord.LobbyUpdate{Metadata: &metadata, Members: &[]discord.LobbyMemberAdd{}})
+	if err != nil {
+		t.Fatalf("unexpected error updating lobby: %v", err)
+	}
+	if updated.Metadata["mode"] != "casual" || len(updated.Members) != 0 {
+		t.Errorf("unexpected updated lobby: %+v", updated)
+	}
+
+	if err = client.DeleteLobby(lobby.ID); err != nil {
+		t.Fatalf("unexpected error deleting lobby: %v", err)
+	}
+	if _, ok := fake.Lobby(lobby.ID); ok {
+		t.Error("expected the lobby to be deleted")
+	}
+	_, err = client.GetLobby(lobby.ID)
+	assertJSONErrorCode(t, err, rest.JSONErrorCodeUnknownLobby)
+}
+
+func TestLobbies_BearerRoutes(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		auth   string
+		body   string
+	}
+	var last request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		last = request{method: r.Method, path: r.URL.Path, auth: r.Header.Get("Authorization"), body: strings.TrimSpace(string(body))}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","application_id":"2","metadata":null,"members":[]}`))
+	}))
+	defer server.Close()
+
+	client := rest.New(rest.NewClient("token", rest.WithURL(server.URL), rest.WithRateLimiter(rest.NewNoopRateLimiter())))
+	bearer := rest.WithToken(discord.TokenTypeBearer, "bearer")
+	tests := []struct {
+		name string
+		do   func() error
+		want request
+	}{
+		{
+			name: "leave",
+			do:   func() error { return client.LeaveLobby(1, bearer) },
+			want: request{method: http.MethodDelete, path: "/lobbies/1/members/@me"},
+		},
+		{
+			name: "link channel",
+			do: func() error {
+				_, err := client.LinkLobbyChannel(1, 4, bearer)
+				return err
+			},
+			want: request{method: http.MethodPatch, path: "/lobbies/1/channel-linking", body: `{"channel_id":"4"}`},
+		},
+		{
+			name: "unlink channel",
+			do: func() error {
+				_, err := client.UnlinkLobbyChannel(1, bearer)
+				return err
+			},
+			want: request{method: http.MethodPatch, path: "/lobbies/1/channel-linking", body: `{}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.do(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.want.auth = "Bearer bearer"
+			if last != tt.want {
+				t.Errorf("expected request %+v, got %+v", tt.want, last)
+			}
+		})
+	}
+}
+
+func assertJSONErrorCode(t *testing.T, err error, code rest.JSONErrorCode) {
+	t.Helper()
+	var restErr *rest.Error
+	if !errors.As(err, &restErr) {
+		t.Fatalf("expected *rest.Error, got %v", err)
+	}
+	if restErr.Code != code {
+		t.Errorf("expected error code %d, got %d", code, restErr.Code)
+	}
+}
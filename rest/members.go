@@ -1,6 +1,9 @@
 package rest
 
 import (
+	"context"
+	"iter"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -15,6 +18,8 @@ func NewMembers(client Client) Members {
 type Members interface {
 	GetMember(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.Member, error)
 	GetMembers(guildID snowflake.ID, limit int, after snowflake.ID, opts ...RequestOpt) ([]discord.Member, error)
+	// MembersIter returns an iterator over the members of a guild with a user ID after startID. It stops after limit members, or never if limit is 0.
+	MembersIter(ctx context.Context, guildID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.Member, error]
 	SearchMembers(guildID snowflake.ID, query string, limit int, opts ...RequestOpt) ([]discord.Member, error)
 	AddMember(guildID snowflake.ID, userID snowflake.ID, memberAdd discord.MemberAdd, opts ...RequestOpt) (*discord.Member, error)
 	RemoveMember(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -57,6 +62,15 @@ func (s *memberImpl) GetMembers(guildID snowflake.ID, limit int, after snowflake
 	return
 }
 
+func (s *memberImpl) MembersIter(ctx context.Context, guildID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.Member, error] {
+	opts = withIterCtx(ctx, opts)
+	return iterPages(ctx, PageDirectionForward, startID, limit, func(_ snowflake.ID, after snowflake.ID) ([]discord.Member, error) {
+		return s.GetMembers(guildID, 1000, after, opts...)
+	}, func(member discord.Member) snowflake.ID {
+		return member.User.ID
+	})
+}
+
 func (s *memberImpl) SearchMembers(guildID snowflake.ID, query string, limit int, opts ...RequestOpt) (members []discord.Member, err error) {
 	values := discord.QueryValues{}
 	if query != "" {
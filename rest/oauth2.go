@@ -42,6 +42,11 @@ type OAuth2 interface {
 
 	GetAccessToken(clientID snowflake.ID, clientSecret string, code string, redirectURI string, opts ...RequestOpt) (*discord.AccessTokenResponse, error)
 	RefreshAccessToken(clientID snowflake.ID, clientSecret string, refreshToken string, opts ...RequestOpt) (*discord.AccessTokenResponse, error)
+	// GetClientCredentialsAccessToken returns an access token of the application's owner with the given scopes using the client credentials grant.
+	// For teams, only the discord.OAuth2ScopeIdentify & discord.OAuth2ScopeApplicationsCommandsUpdate scopes are supported.
+	GetClientCredentialsAccessToken(clientID snowflake.ID, clientSecret string, scopes []discord.OAuth2Scope, opts ...RequestOpt) (*discord.AccessTokenResponse, error)
+	// RevokeToken revokes the given access or refresh token. Revoking either revokes both of them.
+	RevokeToken(clientID snowflake.ID, clientSecret string, token string, opts ...RequestOpt) error
 }
 
 type oAuth2Impl struct {
@@ -149,7 +154,7 @@ func (s *oAuth2Impl) DeleteCurrentUserApplicationRoleConnection(bearerToken stri
 	return s.client.Do(DeleteCurrentUserApplicationRoleConnection.Compile(nil, applicationID), nil, nil, withBearerToken(bearerToken, opts)...)
 }
 
-func (s *oAuth2Impl) exchangeAccessToken(clientID snowflake.ID, clientSecret string, grantType discord.GrantType, codeOrRefreshToken string, redirectURI string, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
+func (s *oAuth2Impl) exchangeAccessToken(clientID snowflake.ID, clientSecret string, grantType discord.GrantType, codeOrRefreshTokenOrScope string, redirectURI string, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
 	values := url.Values{
 		"client_id":     []string{clientID.String()},
 		"client_secret": []string{clientSecret},
@@ -157,11 +162,14 @@ func (s *oAuth2Impl) exchangeAccessToken(clientID snowflake.ID, clientSecret str
 	}
 	switch grantType {
 	case discord.GrantTypeAuthorizationCode:
-		values["code"] = []string{codeOrRefreshToken}
+		values["code"] = []string{codeOrRefreshTokenOrScope}
 		values["redirect_uri"] = []string{redirectURI}
 
 	case discord.GrantTypeRefreshToken:
-		values["refresh_token"] = []string{codeOrRefreshToken}
+		values["refresh_token"] = []string{codeOrRefreshTokenOrScope}
+
+	case discord.GrantTypeClientCredentials:
+		values["scope"] = []string{codeOrRefreshTokenOrScope}
 	}
 	err = s.client.Do(Token.Compile(nil), values, &exchange, opts...)
 	return
@@ -174,3 +182,16 @@ func (s *oAuth2Impl) GetAccessToken(clientID snowflake.ID, clientSecret string,
 func (s *oAuth2Impl) RefreshAccessToken(clientID snowflake.ID, clientSecret string, refreshToken string, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
 	return s.exchangeAccessToken(clientID, clientSecret, discord.GrantTypeRefreshToken, refreshToken, "", opts...)
 }
+
+func (s *oAuth2Impl) GetClientCredentialsAccessToken(clientID snowflake.ID, clientSecret string, scopes []discord.OAuth2Scope, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
+	return s.exchangeAccessToken(clientID, clientSecret, discord.GrantTypeClientCredentials, discord.JoinScopes(scopes), "", opts...)
+}
+
+func (s *oAuth2Impl) RevokeToken(clientID snowflake.ID, clientSecret string, token string, opts ...RequestOpt) error {
+	values := url.Values{
+		"client_id":     []string{clientID.String()},
+		"client_secret": []string{clientSecret},
+		"token":         []string{token},
+	}
+	return s.client.Do(RevokeToken.Compile(nil), values, nil, opts...)
+}
@@ -1,7 +1,11 @@
 package rest
 
 import (
+	"cmp"
+	"context"
 	"errors"
+	"iter"
+	"slices"
 	"time"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -168,3 +172,136 @@ func (p *ChannelPinsPage) Previous() bool {
 	}
 	return p.Err == nil
 }
+
+// PageDirection is the direction in which an iterator walks through a paginated endpoint.
+type PageDirection int
+
+const (
+	// PageDirectionBackward walks from newer to older items by using the "before" query parameter.
+	PageDirectionBackward PageDirection = iota
+	// PageDirectionForward walks from older to newer items by using the "after" query parameter.
+	PageDirectionForward
+)
+
+// forwardStartID returns the ID to start a forward iteration at for endpoints which return the newest items without an "after" parameter.
+// As a zero ID is not sent, it is replaced by the smallest ID after which all items follow.
+func forwardStartID(direction PageDirection, startID snowflake.ID) snowflake.ID {
+	if direction == PageDirectionForward && startID == 0 {
+		return 1
+	}
+	return startID
+}
+
+// iterPages returns an iterator which requests pages via getItems until no more items are returned, the limit is reached or the context is done.
+// Discord returns most pages newest first regardless of the direction, so each page is sorted by ID in the direction of the iteration before it is yielded.
+// A limit of 0 means no limit.
+func iterPages[T any](ctx context.Context, direction PageDirection, startID snowflake.ID, limit int, getItems func(before snowflake.ID, after snowflake.ID) ([]T, error), getID func(t T) snowflake.ID) iter.Seq2[T, error] {
+	return iterPagesHasMore(ctx, direction, startID, limit, func(before snowflake.ID, after snowflake.ID) ([]T, bool, error) {
+		items, err := getItems(before, after)
+		return items, true, err
+	}, getID)
+}
+
+// iterPagesHasMore is like iterPages for endpoints which report whether there are more items, which saves requesting an empty last page.
+func iterPagesHasMore[T any](ctx context.Context, direction PageDirection, startID snowflake.ID, limit int, getItems func(before snowflake.ID, after snowflake.ID) ([]T, bool, error), getID func(t T) snowflake.ID) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		cursor := startID
+		count := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var before, after snowflake.ID
+			if direction == PageDirectionForward {
+				after = cursor
+			} else {
+				before = cursor
+			}
+
+			items, hasMore, err := getItems(before, after)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if len(items) == 0 {
+				return
+			}
+			slices.SortFunc(items, func(a T, b T) int {
+				if direction == PageDirectionForward {
+					return cmp.Compare(getID(a), getID(b))
+				}
+				return cmp.Compare(getID(b), getID(a))
+			})
+
+			for _, item := range items {
+				if limit > 0 && count >= limit {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				count++
+			}
+			if !hasMore {
+				return
+			}
+
+			next := getID(items[len(items)-1])
+			// the cursor did not move, requesting the same page again would loop forever
+			if direction == PageDirectionForward && next <= cursor || direction == PageDirectionBackward && cursor != 0 && next >= cursor {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// iterTimePages returns an iterator which walks backward through a paginated endpoint that uses a timestamp as "before" cursor.
+// It stops when getItems reports that there are no more items, the limit is reached or the context is done.
+// A limit of 0 means no limit.
+func iterTimePages[T any](ctx context.Context, start time.Time, limit int, getItems func(before time.Time) ([]T, bool, error), getTime func(t T) time.Time) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		cursor := start
+		count := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, hasMore, err := getItems(cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			next := cursor
+			for _, item := range items {
+				if limit > 0 && count >= limit {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				count++
+
+				if t := getTime(item); next.IsZero() || t.Before(next) {
+					next = t
+				}
+			}
+			if !hasMore || len(items) == 0 || next.Equal(cursor) {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// withIterCtx prepends the iterator context to the request options, so it can still be overridden by a custom WithCtx.
+func withIterCtx(ctx context.Context, opts []RequestOpt) []RequestOpt {
+	return append([]RequestOpt{WithCtx(ctx)}, opts...)
+}
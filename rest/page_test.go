@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+func fakeIDPages(ids []snowflake.ID, pageSize int) func(before snowflake.ID, after snowflake.ID) ([]snowflake.ID, error) {
+	return func(before snowflake.ID, after snowflake.ID) ([]snowflake.ID, error) {
+		var page []snowflake.ID
+		if after != 0 || before == 0 && after == 0 {
+			for _, id := range ids {
+				if id > after && len(page) < pageSize {
+					page = append(page, id)
+				}
+			}
+			return page, nil
+		}
+		for _, id := range slices.Backward(ids) {
+			if id < before && len(page) < pageSize {
+				page = append(page, id)
+			}
+		}
+		return page, nil
+	}
+}
+
+func TestIterPages(t *testing.T) {
+	ids := []snowflake.ID{1, 2, 3, 4, 5, 6, 7}
+	getID := func(id snowflake.ID) snowflake.ID { return id }
+
+	data := []struct {
+		Name      string
+		Direction PageDirection
+		StartID   snowflake.ID
+		Limit     int
+		Expected  []snowflake.ID
+	}{
+		{
+			Name:      "forward",
+			Direction: PageDirectionForward,
+			Expected:  []snowflake.ID{1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			Name:      "forward with start",
+			Direction: PageDirectionForward,
+			StartID:   4,
+			Expected:  []snowflake.ID{5, 6, 7},
+		},
+		{
+			Name:      "backward with limit",
+			Direction: PageDirectionBackward,
+			StartID:   8,
+			Limit:     4,
+			Expected:  []snowflake.ID{7, 6, 5, 4},
+		},
+	}
+
+	// Discord returns pages newest first regardless of the direction
+	newestFirst := func(getItems func(before snowflake.ID, after snowflake.ID) ([]snowflake.ID, error)) func(before snowflake.ID, after snowflake.ID) ([]snowflake.ID, error) {
+		return func(before snowflake.ID, after snowflake.ID) ([]snowflake.ID, error) {
+			page, err := getItems(before, after)
+			slices.SortFunc(page, func(a snowflake.ID, b snowflake.ID) int { return int(b) - int(a) })
+			return page, err
+		}
+	}
+
+	for _, d := range data {
+		t.Run(d.Name, func(t *testing.T) {
+			for _, getItems := range []func(before snowflake.ID, after snowflake.ID) ([]snowflake.ID, error){fakeIDPages(ids, 3), newestFirst(fakeIDPages(ids, 3))} {
+				var got []snowflake.ID
+				for id, err := range iterPages(context.Background(), d.Direction, d.StartID, d.Limit, getItems, getID) {
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					got = append(got, id)
+				}
+				if !slices.Equal(got, d.Expected) {
+					t.Errorf("expected %v, got %v", d.Expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIterPages_Error(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, err := range iterPages(ctx, PageDirectionForward, 0, 0, fakeIDPages(nil, 1), func(id snowflake.ID) snowflake.ID { return id }) {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected %v, got %v", context.Canceled, err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package proxy
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+// DefaultURL is the upstream URL requests are forwarded to by default.
+// The path of incoming requests including the /api/v{version} prefix is appended to it.
+const DefaultURL = "https://discord.com"
+
+// DefaultIdleTimeout is the time after which the rest.RateLimiter of a token which received no requests is closed by default.
+const DefaultIdleTimeout = 10 * time.Minute
+
+func defaultConfig() config {
+	return config{
+		Logger:      slog.Default(),
+		HTTPClient:  &http.Client{Timeout: 20 * time.Second},
+		URL:         DefaultURL,
+		IdleTimeout: DefaultIdleTimeout,
+	}
+}
+
+type config struct {
+	Logger                *slog.Logger
+	HTTPClient            *http.Client
+	URL                   string
+	IdleTimeout           time.Duration
+	RateLimiterConfigOpts []rest.RateLimiterConfigOpt
+}
+
+// ConfigOpt can be used to supply optional parameters to New
+type ConfigOpt func(config *config)
+
+func (c *config) apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "rest_proxy"))
+}
+
+// WithLogger applies a custom logger to the Proxy
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *config) {
+		config.Logger = logger
+	}
+}
+
+// WithHTTPClient applies a custom http.Client used to forward requests
+func WithHTTPClient(httpClient *http.Client) ConfigOpt {
+	return func(config *config) {
+		config.HTTPClient = httpClient
+	}
+}
+
+// WithURL sets the upstream URL requests are forwarded to, see DefaultURL
+func WithURL(url string) ConfigOpt {
+	return func(config *config) {
+		config.URL = url
+	}
+}
+
+// WithRateLimiterConfigOpts lets you configure the rest.RateLimiter created for each token
+func WithRateLimiterConfigOpts(opts ...rest.RateLimiterConfigOpt) ConfigOpt {
+	return func(config *config) {
+		config.RateLimiterConfigOpts = append(config.RateLimiterConfigOpts, opts...)
+	}
+}
+
+// WithIdleTimeout sets after how long without requests the rest.RateLimiter of a token is closed, see DefaultIdleTimeout.
+// Its rate limit state is lost, so it should be longer than most rate limit resets. 0 keeps all rest.RateLimiter(s) until the Proxy is closed.
+func WithIdleTimeout(idleTimeout time.Duration) ConfigOpt {
+	return func(config *config) {
+		config.IdleTimeout = idleTimeout
+	}
+}
@@ -0,0 +1,18 @@
+// Package proxy provides an HTTP proxy for the Discord REST API which applies rate limits centrally.
+//
+// Requests are forwarded verbatim and grouped by their Authorization header, every token gets its own rest.RateLimiter.
+// The rest.RateLimiter of a token is closed after it received no requests for the idle timeout, see WithIdleTimeout.
+// This allows many services to share one rate limit without each of them tracking it:
+//
+//	p := proxy.New()
+//	defer p.Close(context.TODO())
+//
+//	_ = http.ListenAndServe(":7979", p)
+//
+// Clients then point at the proxy and disable their own rate limiter:
+//
+//	client := rest.NewClient(token,
+//		rest.WithURL("http://localhost:7979/api/v"+strconv.Itoa(rest.Version)),
+//		rest.WithRateLimiter(rest.NewNoopRateLimiter()),
+//	)
+package proxy
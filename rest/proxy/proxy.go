@@ -0,0 +1,276 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+// hopHeaders are the headers which only apply to a single connection and are not forwarded.
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// idParams maps the path segment in front of an ID to the name of its url parameter, see rest.MajorParameters.
+var idParams = map[string]string{
+	"guilds":       "guild.id",
+	"channels":     "channel.id",
+	"webhooks":     "webhook.id",
+	"interactions": "interaction.id",
+}
+
+// tokenParams maps the path segment in front of an ID which is followed by a token to the name of the token's url parameter.
+var tokenParams = map[string]string{
+	"webhooks":     "webhook.token",
+	"interactions": "interaction.token",
+}
+
+// Proxy is a http.Handler which forwards Discord REST API requests while applying a rest.RateLimiter per token.
+type Proxy interface {
+	http.Handler
+
+	// Close closes the rest.RateLimiter(s) of all tokens.
+	Close(ctx context.Context)
+}
+
+// New returns a new Proxy with the given ConfigOpt(s).
+func New(opts ...ConfigOpt) Proxy {
+	cfg := defaultConfig()
+	cfg.apply(opts)
+
+	p := &proxyImpl{
+		config:       cfg,
+		rateLimiters: map[string]*rateLimiterEntry{},
+		done:         make(chan struct{}),
+	}
+	if cfg.IdleTimeout > 0 {
+		go p.evictIdle()
+	}
+	return p
+}
+
+var _ Proxy = (*proxyImpl)(nil)
+
+type proxyImpl struct {
+	config config
+
+	rateLimitersMu sync.Mutex
+	rateLimiters   map[string]*rateLimiterEntry
+
+	// done is closed when the Proxy is closed to stop the eviction of idle rate limiters
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// rateLimiterEntry is the rest.RateLimiter of a token and tracks when it was last used.
+type rateLimiterEntry struct {
+	rateLimiter rest.RateLimiter
+	// active is the number of requests currently using the rest.RateLimiter
+	active   int
+	lastUsed time.Time
+}
+
+func (p *proxyImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	endpoint := compileEndpoint(r.Method, r.URL.Path, auth)
+	endpoint.URL = p.config.URL + r.URL.RequestURI()
+	rateLimiter := p.acquireRateLimiter(auth)
+	defer p.releaseRateLimiter(auth)
+
+	if err := rateLimiter.Wait(r.Context(), endpoint); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			p.config.Logger.Error("error while waiting for rate limit", slog.String("route", endpoint.Endpoint.Route), slog.Any("err", err))
+		}
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	rq, err := http.NewRequestWithContext(r.Context(), r.Method, endpoint.URL, r.Body)
+	if err != nil {
+		_ = rateLimiter.Unlock(endpoint, nil)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rq.ContentLength = r.ContentLength
+	rq.Header = r.Header.Clone()
+	removeHopHeaders(rq.Header)
+
+	rs, err := p.config.HTTPClient.Do(rq)
+	if unlockErr := rateLimiter.Unlock(endpoint, rs); unlockErr != nil {
+		p.config.Logger.Error("error while unlocking rate limit", slog.String("route", endpoint.Endpoint.Route), slog.Any("err", unlockErr))
+	}
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			p.config.Logger.Error("error while forwarding request", slog.String("route", endpoint.Endpoint.Route), slog.Any("err", err))
+		}
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer rs.Body.Close()
+
+	header := w.Header()
+	for key, values := range rs.Header {
+		header[key] = values
+	}
+	removeHopHeaders(header)
+	w.WriteHeader(rs.StatusCode)
+	if _, err = io.Copy(w, rs.Body); err != nil {
+		p.config.Logger.Debug("error while copying response body", slog.String("route", endpoint.Endpoint.Route), slog.Any("err", err))
+	}
+}
+
+func (p *proxyImpl) Close(ctx context.Context) {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+
+	p.rateLimitersMu.Lock()
+	defer p.rateLimitersMu.Unlock()
+	for auth, entry := range p.rateLimiters {
+		entry.rateLimiter.Close(ctx)
+		delete(p.rateLimiters, auth)
+	}
+}
+
+// acquireRateLimiter returns the rest.RateLimiter of the given Authorization header and creates it if needed.
+// It is not evicted until releaseRateLimiter is called.
+func (p *proxyImpl) acquireRateLimiter(auth string) rest.RateLimiter {
+	p.rateLimitersMu.Lock()
+	defer p.rateLimitersMu.Unlock()
+	entry, ok := p.rateLimiters[auth]
+	if !ok {
+		entry = &rateLimiterEntry{
+			rateLimiter: rest.NewRateLimiter(append([]rest.RateLimiterConfigOpt{rest.WithRateLimiterLogger(p.config.Logger)}, p.config.RateLimiterConfigOpts...)...),
+		}
+		p.rateLimiters[auth] = entry
+	}
+	entry.active++
+	return entry.rateLimiter
+}
+
+func (p *proxyImpl) releaseRateLimiter(auth string) {
+	p.rateLimitersMu.Lock()
+	defer p.rateLimitersMu.Unlock()
+	if entry, ok := p.rateLimiters[auth]; ok {
+		entry.active--
+		entry.lastUsed = time.Now()
+	}
+}
+
+func (p *proxyImpl) evictIdle() {
+	ticker := time.NewTicker(p.config.IdleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.doEvictIdle()
+		}
+	}
+}
+
+// doEvictIdle closes & removes all rest.RateLimiter(s) which are not in use and were last used before the idle timeout.
+func (p *proxyImpl) doEvictIdle() {
+	var idle []rest.RateLimiter
+	p.rateLimitersMu.Lock()
+	now := time.Now()
+	for auth, entry := range p.rateLimiters {
+		if entry.active == 0 && now.Sub(entry.lastUsed) >= p.config.IdleTimeout {
+			idle = append(idle, entry.rateLimiter)
+			delete(p.rateLimiters, auth)
+		}
+	}
+	remaining := len(p.rateLimiters)
+	p.rateLimitersMu.Unlock()
+
+	if len(idle) > 0 {
+		p.config.Logger.Debug("closing idle rate limiters", slog.Int("count", len(idle)), slog.Int("remaining", remaining))
+	}
+	for _, rateLimiter := range idle {
+		// no requests are using the rate limiter, so closing it doesn't wait
+		rateLimiter.Close(context.Background())
+	}
+}
+
+// compileEndpoint builds the rest.CompiledEndpoint of a request from its path.
+// IDs & tokens are replaced with url parameters in the route and major parameters are extracted, so requests share the buckets Discord uses.
+func compileEndpoint(method string, path string, auth string) *rest.CompiledEndpoint {
+	path = strings.TrimPrefix(path, "/api")
+	if version, ok := strings.CutPrefix(path, "/v"); ok {
+		if i := strings.IndexByte(version, '/'); i > 0 && isID(version[:i]) {
+			path = version[i:]
+		}
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	route := make([]string, len(segments))
+	var majorParams []string
+	for i, segment := range segments {
+		route[i] = segment
+		if i == 0 {
+			continue
+		}
+
+		var param string
+		switch {
+		case segments[i-1] == "reactions":
+			param = "emoji"
+		case isID(segment):
+			param = "id"
+			if name, ok := idParams[segments[i-1]]; ok {
+				param = name
+			}
+		case i > 1 && isID(segments[i-1]) && tokenParams[segments[i-2]] != "":
+			param = tokenParams[segments[i-2]]
+		default:
+			continue
+		}
+		route[i] = "{" + param + "}"
+		if param != "id" && strings.Contains(rest.MajorParameters, param) {
+			majorParams = append(majorParams, param+"="+segment)
+		}
+	}
+
+	return &rest.CompiledEndpoint{
+		Endpoint: &rest.Endpoint{
+			Method:  method,
+			Route:   "/" + strings.Join(route, "/"),
+			BotAuth: strings.HasPrefix(auth, "Bot "),
+		},
+		MajorParams: strings.Join(majorParams, ":"),
+	}
+}
+
+func isID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func removeHopHeaders(header http.Header) {
+	for _, key := range hopHeaders {
+		header.Del(key)
+	}
+}
@@ -0,0 +1,125 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/resttest"
+)
+
+func TestCompileEndpoint(t *testing.T) {
+	tests := []struct {
+		path        string
+		auth        string
+		route       string
+		majorParams string
+		botAuth     bool
+	}{
+		{"/api/v10/users/@me", "Bot token", "/users/@me", "", true},
+		{"/api/v10/channels/1/messages/2", "Bot token", "/channels/{channel.id}/messages/{id}", "channel.id=1", true},
+		{"/api/v10/channels/1/messages/2/reactions/emoji:3/@me", "Bot token", "/channels/{channel.id}/messages/{id}/reactions/{emoji}/@me", "channel.id=1", true},
+		{"/api/v10/guilds/1/members/2", "Bearer token", "/guilds/{guild.id}/members/{id}", "guild.id=1", false},
+		{"/api/v10/webhooks/1/token/messages/@original", "", "/webhooks/{webhook.id}/{webhook.token}/messages/@original", "webhook.id=1", false},
+		{"/api/v10/interactions/1/token/callback", "", "/interactions/{interaction.id}/{interaction.token}/callback", "interaction.token=token", false},
+		{"/channels/1", "Bot token", "/channels/{channel.id}", "channel.id=1", true},
+	}
+	for _, tt := range tests {
+		endpoint := compileEndpoint(http.MethodGet, tt.path, tt.auth)
+		if endpoint.Endpoint.Route != tt.route || endpoint.MajorParams != tt.majorParams || endpoint.Endpoint.BotAuth != tt.botAuth {
+			t.Errorf("%s: got route %q, major params %q & bot auth %t", tt.path, endpoint.Endpoint.Route, endpoint.MajorParams, endpoint.Endpoint.BotAuth)
+		}
+	}
+}
+
+func TestProxy_RateLimit(t *testing.T) {
+	fake := resttest.NewServer(resttest.WithRateLimit(2, 200*time.Millisecond))
+	defer fake.Close()
+
+	var requests, rateLimited atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		rec := &statusRecorder{ResponseWriter: w}
+		http.StripPrefix("/api/v10", fake.Config.Handler).ServeHTTP(rec, r)
+		if rec.status == http.StatusTooManyRequests {
+			rateLimited.Add(1)
+		}
+	}))
+	defer upstream.Close()
+
+	p := New(WithURL(upstream.URL))
+	defer p.Close(context.Background())
+	server := httptest.NewServer(p)
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for range 3 {
+		client := rest.NewOAuth2(rest.NewClient("token", rest.WithURL(server.URL+"/api/v10"), rest.WithRateLimiter(rest.NewNoopRateLimiter())))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 2 {
+				if _, err := client.GetCurrentUser(""); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 6 {
+		t.Errorf("expected 6 upstream requests, got %d", got)
+	}
+	if got := rateLimited.Load(); got != 0 {
+		t.Errorf("expected no rate limited requests, got %d", got)
+	}
+}
+
+func TestProxy_EvictIdle(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer upstream.Close()
+
+	p := New(WithURL(upstream.URL), WithIdleTimeout(time.Hour)).(*proxyImpl)
+	defer p.Close(context.Background())
+
+	for _, token := range []string{"Bearer a", "Bearer b"} {
+		rq := httptest.NewRequest(http.MethodGet, "/api/v10/users/@me", nil)
+		rq.Header.Set("Authorization", token)
+		p.ServeHTTP(httptest.NewRecorder(), rq)
+	}
+	// simulate a request which is still waiting for its rate limit
+	p.acquireRateLimiter("Bearer b")
+
+	p.rateLimitersMu.Lock()
+	for _, entry := range p.rateLimiters {
+		entry.lastUsed = time.Now().Add(-2 * time.Hour)
+	}
+	p.rateLimitersMu.Unlock()
+	p.doEvictIdle()
+
+	p.rateLimitersMu.Lock()
+	defer p.rateLimitersMu.Unlock()
+	if _, ok := p.rateLimiters["Bearer a"]; ok {
+		t.Error("expected idle rate limiter to be evicted")
+	}
+	if _, ok := p.rateLimiters["Bearer b"]; !ok {
+		t.Error("expected active rate limiter to be kept")
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
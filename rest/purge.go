@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// BulkDeleteMaxAge is the maximum age of messages which can be deleted with Channels.BulkDeleteMessages.
+const BulkDeleteMaxAge = 14 * 24 * time.Hour
+
+// bulkDeleteMargin keeps messages which are almost too old for a bulk delete out of it, as Discord's clock might differ from ours.
+const bulkDeleteMargin = time.Minute
+
+// PurgeOptions decide which messages are deleted by Channels.Purge.
+type PurgeOptions struct {
+	// Filter decides whether a message is deleted. If nil, all messages are deleted.
+	Filter func(message discord.Message) bool
+	// Before only deletes messages sent before the message with this ID. If 0, the purge starts at the newest message.
+	Before snowflake.ID
+	// After only deletes messages sent after the message with this ID. If 0, the purge continues until the oldest message.
+	After snowflake.ID
+	// Limit is the maximum number of messages to delete. If 0, there is no limit.
+	Limit int
+}
+
+// PurgeBatch is a batch of messages deleted by Channels.Purge.
+type PurgeBatch struct {
+	// MessageIDs are the IDs of the messages in the batch.
+	MessageIDs []snowflake.ID
+	// Bulk is true if the messages were deleted with a single bulk delete request.
+	// Messages older than BulkDeleteMaxAge and single messages are deleted one by one.
+	Bulk bool
+}
+
+func (s *channelImpl) Purge(ctx context.Context, channelID snowflake.ID, options PurgeOptions, opts ...RequestOpt) iter.Seq2[PurgeBatch, error] {
+	return func(yield func(PurgeBatch, error) bool) {
+		deleteOpts := withIterCtx(ctx, opts)
+		var messageIDs []snowflake.ID
+		count := 0
+		for message, err := range s.MessagesIter(ctx, channelID, options.Before, PageDirectionBackward, 0, opts...) {
+			if err != nil {
+				yield(PurgeBatch{}, err)
+				return
+			}
+			if options.After != 0 && message.ID <= options.After {
+				break
+			}
+			if options.Filter != nil && !options.Filter(message) {
+				continue
+			}
+
+			messageIDs = append(messageIDs, message.ID)
+			count++
+			if len(messageIDs) == 100 {
+				if !s.purgeBatch(ctx, channelID, messageIDs, yield, deleteOpts) {
+					return
+				}
+				messageIDs = nil
+			}
+			if options.Limit > 0 && count >= options.Limit {
+				break
+			}
+		}
+		if len(messageIDs) > 0 {
+			s.purgeBatch(ctx, channelID, messageIDs, yield, deleteOpts)
+		}
+	}
+}
+
+// purgeBatch deletes up to 100 messages. Messages which are too old for a bulk delete are deleted one by one in a separate batch.
+// It returns false if the iteration should stop.
+func (s *channelImpl) purgeBatch(ctx context.Context, channelID snowflake.ID, messageIDs []snowflake.ID, yield func(PurgeBatch, error) bool, opts []RequestOpt) bool {
+	minBulkID := snowflake.New(time.Now().Add(-BulkDeleteMaxAge + bulkDeleteMargin))
+	var bulkIDs, singleIDs []snowflake.ID
+	for _, messageID := range messageIDs {
+		if messageID >= minBulkID {
+			bulkIDs = append(bulkIDs, messageID)
+		} else {
+			singleIDs = append(singleIDs, messageID)
+		}
+	}
+	// bulk deletes require at least 2 messages
+	if len(bulkIDs) == 1 {
+		singleIDs = append(bulkIDs, singleIDs...)
+		bulkIDs = nil
+	}
+
+	if len(bulkIDs) > 0 {
+		err := s.BulkDeleteMessages(channelID, bulkIDs, opts...)
+		if !yield(PurgeBatch{MessageIDs: bulkIDs, Bulk: true}, err) {
+			return false
+		}
+	}
+	if len(singleIDs) == 0 {
+		return true
+	}
+
+	var errs []error
+	for _, messageID := range singleIDs {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := s.DeleteMessage(channelID, messageID, opts...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return yield(PurgeBatch{MessageIDs: singleIDs}, errors.Join(errs...))
+}
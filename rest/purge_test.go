@@ -0,0 +1,107 @@
+package rest_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/resttest"
+)
+
+func TestPurge(t *testing.T) {
+	fake := resttest.NewServer(resttest.WithRateLimit(1000, time.Second))
+	defer fake.Close()
+	client := rest.New(rest.NewClient("token", rest.WithURL(fake.URL)))
+	channelID := fake.CreateChannel(fake.CreateGuild("test"), discord.ChannelTypeGuildText, "general")
+
+	var first snowflake.ID
+	for i := range 250 {
+		content := "delete"
+		if i%5 == 0 {
+			content = "keep"
+		}
+		msg, err := client.CreateMessage(channelID, discord.MessageCreate{Content: content})
+		if err != nil {
+			t.Fatalf("unexpected error creating message: %v", err)
+		}
+		if i == 0 {
+			first = msg.ID
+		}
+	}
+
+	deleted := 0
+	for batch, err := range client.Purge(context.Background(), channelID, rest.PurgeOptions{
+		Filter: func(message discord.Message) bool {
+			return message.Content == "delete"
+		},
+		After: first,
+		Limit: 150,
+	}) {
+		if err != nil {
+			t.Fatalf("unexpected error purging: %v", err)
+		}
+		if !batch.Bulk || len(batch.MessageIDs) > 100 {
+			t.Errorf("expected a bulk delete of at most 100 messages, got %+v", batch)
+		}
+		deleted += len(batch.MessageIDs)
+	}
+	if deleted != 150 {
+		t.Errorf("expected 150 deleted messages, got %d", deleted)
+	}
+
+	messages := fake.Messages(channelID)
+	if len(messages) != 100 {
+		t.Errorf("expected 100 remaining messages, got %d", len(messages))
+	}
+	// the newest messages are deleted first
+	if messages[len(messages)-1].Content != "keep" || messages[1].Content != "delete" {
+		t.Errorf("expected only the newest messages to be deleted")
+	}
+}
+
+func TestPurge_OldMessages(t *testing.T) {
+	fake := resttest.NewServer(resttest.WithRateLimit(1000, time.Second))
+	defer fake.Close()
+	client := rest.New(rest.NewClient("token", rest.WithURL(fake.URL)))
+	channelID := fake.CreateChannel(fake.CreateGuild("test"), discord.ChannelTypeGuildText, "general")
+
+	now := time.Now()
+	var oldIDs, newIDs []snowflake.ID
+	for i := range 3 {
+		oldIDs = append(oldIDs, fake.CreateMessage(channelID, "old", now.Add(-rest.BulkDeleteMaxAge-time.Duration(i+1)*time.Hour)))
+		newIDs = append(newIDs, fake.CreateMessage(channelID, "new", now.Add(-time.Duration(i+1)*time.Hour)))
+	}
+
+	var batches []rest.PurgeBatch
+	for batch, err := range client.Purge(context.Background(), channelID, rest.PurgeOptions{}) {
+		if err != nil {
+			t.Fatalf("unexpected error purging: %v", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	if len(batches) != 2 {
+		t.Fatalf("expected a bulk and a single delete batch, got %+v", batches)
+	}
+	if !batches[0].Bulk || !sameIDs(batches[0].MessageIDs, newIDs) {
+		t.Errorf("expected a bulk delete of the new messages, got %+v", batches[0])
+	}
+	if batches[1].Bulk || !sameIDs(batches[1].MessageIDs, oldIDs) {
+		t.Errorf("expected single deletes of the old messages, got %+v", batches[1])
+	}
+	if messages := fake.Messages(channelID); len(messages) != 0 {
+		t.Errorf("expected all messages to be deleted, got %d", len(messages))
+	}
+}
+
+func sameIDs(a []snowflake.ID, b []snowflake.ID) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
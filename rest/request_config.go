@@ -11,18 +11,26 @@ import (
 	"github.com/disgoorg/disgo/discord"
 )
 
-func defaultRequestConfig(rq *http.Request) requestConfig {
+func defaultRequestConfig(rq *http.Request, retryPolicy RetryPolicy) requestConfig {
 	return requestConfig{
-		Request: rq,
-		Ctx:     context.TODO(),
+		Request:     rq,
+		Ctx:         context.TODO(),
+		RetryPolicy: retryPolicy,
 	}
 }
 
 type requestConfig struct {
-	Request *http.Request
-	Ctx     context.Context
-	Checks  []Check
-	Delay   time.Duration
+	Request     *http.Request
+	Ctx         context.Context
+	Checks      []Check
+	Delay       time.Duration
+	RetryPolicy RetryPolicy
+	Timings     []*RequestTiming
+	Essential   bool
+	Priority    Priority
+	// NoCoalescing disables WithCoalescing for the request
+	NoCoalescing bool
+	TokenSource  TokenSource
 }
 
 // Check is a function which gets executed right before a request is made
@@ -61,6 +69,41 @@ func WithDelay(delay time.Duration) RequestOpt {
 	}
 }
 
+// WithRequestRetryPolicy overrides the RetryPolicy of the rest client for this request
+func WithRequestRetryPolicy(retryPolicy RetryPolicy) RequestOpt {
+	return func(config *requestConfig) {
+		config.RetryPolicy = retryPolicy
+	}
+}
+
+// WithRequestTiming fills the given RequestTiming while the request is done
+func WithRequestTiming(timing *RequestTiming) RequestOpt {
+	return func(config *requestConfig) {
+		config.Timings = append(config.Timings, timing)
+	}
+}
+
+// WithEssential marks the request as essential, so it is still sent while the RateLimiter refuses requests because of too many invalid requests
+func WithEssential() RequestOpt {
+	return func(config *requestConfig) {
+		config.Essential = true
+	}
+}
+
+// WithPriority sets the Priority of the request. Requests waiting for the same rate limit bucket are sent in order of their Priority.
+func WithPriority(priority Priority) RequestOpt {
+	return func(config *requestConfig) {
+		config.Priority = priority
+	}
+}
+
+// WithoutCoalescing sends the request even if an identical request is already in flight, see WithCoalescing
+func WithoutCoalescing() RequestOpt {
+	return func(config *requestConfig) {
+		config.NoCoalescing = true
+	}
+}
+
 // WithHeader adds a custom header to the request
 func WithHeader(key string, value string) RequestOpt {
 	return func(config *requestConfig) {
@@ -84,6 +127,20 @@ func WithToken(tokenType discord.TokenType, token string) RequestOpt {
 	return WithHeader("Authorization", tokenType.Apply(token))
 }
 
+// TokenSource supplies the token of requests made with WithTokenSource.
+type TokenSource interface {
+	// Token returns the type & value of a valid token. It is called before every attempt of a request.
+	Token(ctx context.Context) (discord.TokenType, string, error)
+}
+
+// WithTokenSource authorizes the request with the token returned by the TokenSource, e.g. an oauth2.TokenSource.
+// It takes precedence over WithToken.
+func WithTokenSource(tokenSource TokenSource) RequestOpt {
+	return func(config *requestConfig) {
+		config.TokenSource = tokenSource
+	}
+}
+
 // WithQueryParam applies a custom query parameter to the request
 func WithQueryParam(param string, value any) RequestOpt {
 	return func(config *requestConfig) {
@@ -24,6 +24,7 @@ type Rest interface {
 	Stickers
 	SKUs
 	GuildScheduledEvents
+	Lobbies
 }
 
 var _ Rest = (*restImpl)(nil)
@@ -55,6 +56,7 @@ func New(client Client, opts ...ConfigOpt) Rest {
 		Stickers:             NewStickers(client),
 		SKUs:                 NewSKUs(client),
 		GuildScheduledEvents: NewGuildScheduledEvents(client),
+		Lobbies:              NewLobbies(client),
 	}
 }
 
@@ -81,4 +83,5 @@ type restImpl struct {
 	Stickers
 	SKUs
 	GuildScheduledEvents
+	Lobbies
 }
@@ -0,0 +1,236 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Bucket is the rate limit state of a single route as reported by the X-RateLimit-* headers.
+type Bucket struct {
+	ID        string    `json:"id"`
+	Reset     time.Time `json:"reset"`
+	Remaining int       `json:"remaining"`
+	Limit     int       `json:"limit"`
+}
+
+// BucketStore holds the rate limit buckets & the global rate limit of a RateLimiter.
+// Implementations can share this state between multiple processes using the same token.
+type BucketStore interface {
+	// Lock waits until no one else holds the bucket for the given route hash and locks it.
+	// It returns the current state of the bucket and the time until which the global rate limit applies.
+	// Callers waiting for the same bucket should be served in order of their RequestPriority.
+	Lock(ctx context.Context, hash string) (Bucket, time.Time, error)
+
+	// Unlock unlocks the bucket for the given route hash. If update is not nil, it is called with the stored bucket before unlocking.
+	Unlock(hash string, update func(bucket *Bucket)) error
+
+	// SetGlobalReset sets the time until which the global rate limit applies.
+	SetGlobalReset(reset time.Time) error
+
+	// Reset clears all buckets & the global rate limit.
+	Reset()
+
+	// Close waits until all locked buckets are unlocked or the context is done.
+	Close(ctx context.Context)
+}
+
+// RateLimitSnapshotter is optionally implemented by a RateLimiter or BucketStore which can report its current state.
+// The RateLimiter(s) & BucketStore(s) of this package implement it.
+//
+//	if snapshotter, ok := rateLimiter.(rest.RateLimitSnapshotter); ok {
+//		snapshot, err := snapshotter.Snapshot()
+//	}
+type RateLimitSnapshotter interface {
+	// Snapshot returns the current state of all known buckets & the global rate limit.
+	Snapshot() (RateLimitSnapshot, error)
+}
+
+// ErrSnapshotNotSupported is returned when a snapshot is requested from a BucketStore which does not implement RateLimitSnapshotter.
+var ErrSnapshotNotSupported = errors.New("bucket store does not support snapshots")
+
+// RateLimitSnapshot is the state of all rate limit buckets at a point in time.
+type RateLimitSnapshot struct {
+	// Buckets maps the route hash (method + route + major parameters) to its bucket.
+	Buckets map[string]Bucket `json:"buckets"`
+	// GlobalReset is the time until which the global rate limit applies. It is zero or in the past if there is no global rate limit.
+	GlobalReset time.Time `json:"global_reset"`
+	// InvalidRequests is the number of 401, 403 & 429 responses the RateLimiter received in the last InvalidRequestWindow.
+	// It is not shared by BucketStore(s).
+	InvalidRequests int `json:"invalid_requests"`
+	// InvalidRequestsBlocked is true while the RateLimiter refuses non-essential requests, see WithInvalidRequestThresholds.
+	InvalidRequestsBlocked bool `json:"invalid_requests_blocked"`
+}
+
+var (
+	_ BucketStore          = (*memoryBucketStore)(nil)
+	_ RateLimitSnapshotter = (*memoryBucketStore)(nil)
+)
+
+// NewMemoryBucketStore returns a new BucketStore which keeps all buckets in process memory.
+// Buckets which are past their reset are removed every cleanupInterval until the BucketStore is closed.
+// This is the default BucketStore used by NewRateLimiter.
+func NewMemoryBucketStore(logger *slog.Logger, cleanupInterval time.Duration) BucketStore {
+	store := &memoryBucketStore{
+		logger:  logger,
+		buckets: map[string]*memoryBucket{},
+		done:    make(chan struct{}),
+	}
+
+	go store.cleanup(cleanupInterval)
+
+	return store
+}
+
+type memoryBucketStore struct {
+	logger *slog.Logger
+
+	// global Rate Limit
+	global time.Time
+
+	// Hash + Major Parameter -> bucket
+	buckets   map[string]*memoryBucket
+	bucketsMu sync.Mutex
+
+	// done is closed when the store is closed to stop the cleanup
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type memoryBucket struct {
+	mu priorityMutex
+	Bucket
+}
+
+func (s *memoryBucketStore) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.doCleanup()
+		}
+	}
+}
+
+func (s *memoryBucketStore) doCleanup() {
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+	before := len(s.buckets)
+	now := time.Now()
+	for hash, b := range s.buckets {
+		if !b.mu.TryLock() {
+			continue
+		}
+		if b.Reset.Before(now) {
+			s.logger.Debug("cleaning up bucket", slog.String("hash", hash), slog.String("id", b.ID), slog.Time("reset", b.Reset))
+			delete(s.buckets, hash)
+		}
+		b.mu.Unlock()
+	}
+	if before != len(s.buckets) {
+		s.logger.Debug("cleaned up rate limit buckets", slog.Int("before", before), slog.Int("after", len(s.buckets)), slog.Int("removed", before-len(s.buckets)))
+	}
+}
+
+func (s *memoryBucketStore) getBucket(hash string, create bool) *memoryBucket {
+	s.logger.Debug("locking buckets")
+	s.bucketsMu.Lock()
+	defer func() {
+		s.logger.Debug("unlocking buckets")
+		s.bucketsMu.Unlock()
+	}()
+	b, ok := s.buckets[hash]
+	if !ok {
+		if !create {
+			return nil
+		}
+
+		b = &memoryBucket{
+			Bucket: Bucket{
+				Remaining: 1,
+				// we don't know the limit yet
+				Limit: -1,
+			},
+		}
+		s.buckets[hash] = b
+	}
+	return b
+}
+
+func (s *memoryBucketStore) Lock(ctx context.Context, hash string) (Bucket, time.Time, error) {
+	b := s.getBucket(hash, true)
+	if err := b.mu.Lock(ctx, RequestPriority(ctx)); err != nil {
+		return Bucket{}, time.Time{}, err
+	}
+
+	// the bucket state is guarded by bucketsMu, so it can be read by Snapshot while the bucket is locked
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+	return b.Bucket, s.global, nil
+}
+
+func (s *memoryBucketStore) Unlock(hash string, update func(bucket *Bucket)) error {
+	b := s.getBucket(hash, false)
+	if b == nil {
+		return nil
+	}
+	if update != nil {
+		s.bucketsMu.Lock()
+		update(&b.Bucket)
+		s.bucketsMu.Unlock()
+	}
+	b.mu.Unlock()
+	return nil
+}
+
+func (s *memoryBucketStore) SetGlobalReset(reset time.Time) error {
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+	s.global = reset
+	return nil
+}
+
+func (s *memoryBucketStore) Reset() {
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+
+	s.global = time.Time{}
+	clear(s.buckets)
+}
+
+func (s *memoryBucketStore) Snapshot() (RateLimitSnapshot, error) {
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
+
+	buckets := make(map[string]Bucket, len(s.buckets))
+	for hash, b := range s.buckets {
+		buckets[hash] = b.Bucket
+	}
+	return RateLimitSnapshot{
+		Buckets:     buckets,
+		GlobalReset: s.global,
+	}, nil
+}
+
+func (s *memoryBucketStore) Close(ctx context.Context) {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+
+	var wg sync.WaitGroup
+	s.bucketsMu.Lock()
+	for i := range s.buckets {
+		wg.Add(1)
+		b := s.buckets[i]
+		go func() {
+			_ = b.mu.Lock(ctx, PriorityNormal)
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,317 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+)
+
+const (
+	bucketStoreOpLock     = "lock"
+	bucketStoreOpUnlock   = "unlock"
+	bucketStoreOpGlobal   = "global"
+	bucketStoreOpReset    = "reset"
+	bucketStoreOpSnapshot = "snapshot"
+)
+
+type bucketStoreRequest struct {
+	Op       string    `json:"op"`
+	Hash     string    `json:"hash,omitempty"`
+	Priority Priority  `json:"priority,omitempty"`
+	Bucket   *Bucket   `json:"bucket,omitempty"`
+	Global   time.Time `json:"global,omitzero"`
+}
+
+type bucketStoreResponse struct {
+	Bucket   Bucket             `json:"bucket"`
+	Global   time.Time          `json:"global"`
+	Snapshot *RateLimitSnapshot `json:"snapshot,omitempty"`
+	Error    string             `json:"error,omitempty"`
+}
+
+func (r bucketStoreResponse) err() error {
+	if r.Error == "" {
+		return nil
+	}
+	return errors.New(r.Error)
+}
+
+var (
+	_ BucketStore          = (*socketBucketStore)(nil)
+	_ RateLimitSnapshotter = (*socketBucketStore)(nil)
+)
+
+// NewSocketBucketStore returns a BucketStore which coordinates all buckets through a BucketStore served by ServeBucketStore on the given network address.
+// Use it with WithBucketStore in every process which shares the same token, for example with "unix" & "/run/disgo/rest.sock" or "tcp" & "127.0.0.1:7070".
+//
+// Each locked bucket holds its own connection to the server, so the buckets of a crashed process are unlocked as soon as its connections are closed.
+func NewSocketBucketStore(network string, address string) BucketStore {
+	return &socketBucketStore{
+		network: network,
+		address: address,
+		locks:   map[string]*socketBucketLock{},
+	}
+}
+
+type socketBucketStore struct {
+	dialer  net.Dialer
+	network string
+	address string
+
+	locks   map[string]*socketBucketLock
+	locksMu sync.Mutex
+	wg      sync.WaitGroup
+}
+
+type socketBucketLock struct {
+	conn   net.Conn
+	enc    jsonEncoder
+	dec    jsonDecoder
+	bucket Bucket
+}
+
+type jsonEncoder interface {
+	Encode(v any) error
+}
+
+type jsonDecoder interface {
+	Decode(v any) error
+}
+
+func (s *socketBucketStore) Lock(ctx context.Context, hash string) (Bucket, time.Time, error) {
+	conn, err := s.dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return Bucket{}, time.Time{}, err
+	}
+	// closing the connection tells the server to stop waiting for the bucket
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+
+	lock := &socketBucketLock{
+		conn: conn,
+		enc:  json.NewEncoder(conn),
+		dec:  json.NewDecoder(conn),
+	}
+
+	var rs bucketStoreResponse
+	if err = lock.enc.Encode(bucketStoreRequest{Op: bucketStoreOpLock, Hash: hash, Priority: RequestPriority(ctx)}); err == nil {
+		err = lock.dec.Decode(&rs)
+	}
+	if !stop() {
+		return Bucket{}, time.Time{}, ctx.Err()
+	}
+	if err == nil {
+		err = rs.err()
+	}
+	if err != nil {
+		_ = conn.Close()
+		return Bucket{}, time.Time{}, err
+	}
+
+	lock.bucket = rs.Bucket
+	s.locksMu.Lock()
+	s.locks[hash] = lock
+	s.wg.Add(1)
+	s.locksMu.Unlock()
+
+	return rs.Bucket, rs.Global, nil
+}
+
+func (s *socketBucketStore) Unlock(hash string, update func(bucket *Bucket)) error {
+	s.locksMu.Lock()
+	lock, ok := s.locks[hash]
+	delete(s.locks, hash)
+	s.locksMu.Unlock()
+	if !ok {
+		return nil
+	}
+	defer s.wg.Done()
+	defer lock.conn.Close()
+
+	rq := bucketStoreRequest{Op: bucketStoreOpUnlock, Hash: hash}
+	if update != nil {
+		update(&lock.bucket)
+		rq.Bucket = &lock.bucket
+	}
+
+	if err := lock.enc.Encode(rq); err != nil {
+		return err
+	}
+	var rs bucketStoreResponse
+	if err := lock.dec.Decode(&rs); err != nil {
+		return err
+	}
+	return rs.err()
+}
+
+func (s *socketBucketStore) SetGlobalReset(reset time.Time) error {
+	_, err := s.do(bucketStoreRequest{Op: bucketStoreOpGlobal, Global: reset})
+	return err
+}
+
+func (s *socketBucketStore) Reset() {
+	_, _ = s.do(bucketStoreRequest{Op: bucketStoreOpReset})
+}
+
+func (s *socketBucketStore) Snapshot() (RateLimitSnapshot, error) {
+	var snapshot RateLimitSnapshot
+	rs, err := s.do(bucketStoreRequest{Op: bucketStoreOpSnapshot})
+	if err == nil && rs.Snapshot != nil {
+		snapshot = *rs.Snapshot
+	}
+	return snapshot, err
+}
+
+func (s *socketBucketStore) do(rq bucketStoreRequest) (bucketStoreResponse, error) {
+	var rs bucketStoreResponse
+	conn, err := s.dialer.Dial(s.network, s.address)
+	if err != nil {
+		return rs, err
+	}
+	defer conn.Close()
+
+	if err = json.NewEncoder(conn).Encode(rq); err != nil {
+		return rs, err
+	}
+	if err = json.NewDecoder(conn).Decode(&rs); err != nil {
+		return rs, err
+	}
+	return rs, rs.err()
+}
+
+func (s *socketBucketStore) Close(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case <-done:
+	}
+}
+
+// ServeBucketStore accepts connections from BucketStore(s) created with NewSocketBucketStore on the listener and coordinates their buckets with the given BucketStore.
+// It blocks until the listener is closed.
+// If store is nil, a new BucketStore created with NewMemoryBucketStore is used.
+func ServeBucketStore(listener net.Listener, store BucketStore, logger *slog.Logger) error {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With(slog.String("name", "rest_bucket_store_server"))
+	if store == nil {
+		store = NewMemoryBucketStore(logger, CleanupInterval)
+	}
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go handleBucketStoreConn(conn, store, logger)
+	}
+}
+
+func handleBucketStoreConn(conn net.Conn, store BucketStore, logger *slog.Logger) {
+	defer conn.Close()
+	enc := json.NewEncoder(conn)
+	dec := json.NewDecoder(conn)
+
+	var rq bucketStoreRequest
+	if err := dec.Decode(&rq); err != nil {
+		logger.Debug("failed to decode bucket store request", slog.Any("err", err))
+		return
+	}
+
+	var rs bucketStoreResponse
+	switch rq.Op {
+	case bucketStoreOpLock:
+		handleBucketStoreLock(rq.Hash, rq.Priority, enc, dec, store, logger)
+		return
+
+	case bucketStoreOpGlobal:
+		if err := store.SetGlobalReset(rq.Global); err != nil {
+			rs.Error = err.Error()
+		}
+
+	case bucketStoreOpReset:
+		store.Reset()
+
+	case bucketStoreOpSnapshot:
+		snapshotter, ok := store.(RateLimitSnapshotter)
+		if !ok {
+			rs.Error = ErrSnapshotNotSupported.Error()
+			break
+		}
+		snapshot, err := snapshotter.Snapshot()
+		if err != nil {
+			rs.Error = err.Error()
+		}
+		rs.Snapshot = &snapshot
+
+	default:
+		rs.Error = "unknown bucket store op: " + rq.Op
+	}
+
+	if err := enc.Encode(rs); err != nil {
+		logger.Debug("failed to encode bucket store response", slog.Any("err", err))
+	}
+}
+
+func handleBucketStoreLock(hash string, priority Priority, enc jsonEncoder, dec jsonDecoder, store BucketStore, logger *slog.Logger) {
+	ctx, cancel := context.WithCancel(withRequestPriority(context.Background(), priority))
+	defer cancel()
+
+	// the unlock request is read in the background, so we notice when the client goes away while waiting for the bucket
+	unlock := make(chan bucketStoreRequest, 1)
+	go func() {
+		defer close(unlock)
+		var rq bucketStoreRequest
+		if err := dec.Decode(&rq); err != nil {
+			cancel()
+			return
+		}
+		unlock <- rq
+	}()
+
+	bucket, global, err := store.Lock(ctx, hash)
+	if err != nil {
+		_ = enc.Encode(bucketStoreResponse{Error: err.Error()})
+		return
+	}
+	if err = enc.Encode(bucketStoreResponse{Bucket: bucket, Global: global}); err != nil {
+		logger.Debug("failed to encode bucket store response", slog.Any("err", err))
+	}
+
+	rq, ok := <-unlock
+	if !ok {
+		logger.Debug("bucket store client disconnected without unlocking", slog.String("hash", hash))
+		_ = store.Unlock(hash, nil)
+		return
+	}
+
+	var update func(bucket *Bucket)
+	if rq.Bucket != nil {
+		update = func(bucket *Bucket) {
+			*bucket = *rq.Bucket
+		}
+	}
+
+	var rs bucketStoreResponse
+	if err = store.Unlock(hash, update); err != nil {
+		rs.Error = err.Error()
+	}
+	if err = enc.Encode(rs); err != nil {
+		logger.Debug("failed to encode bucket store response", slog.Any("err", err))
+	}
+}
@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"testing"
+	"time"
+)
+
+// serveTestBucketStore serves a memory BucketStore on a loopback listener and returns it with a function to create clients for it.
+func serveTestBucketStore(t *testing.T) (*memoryBucketStore, func() *socketBucketStore) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store := NewMemoryBucketStore(logger, time.Minute).(*memoryBucketStore)
+	go func() {
+		_ = ServeBucketStore(listener, store, logger)
+	}()
+
+	return store, func() *socketBucketStore {
+		return NewSocketBucketStore("tcp", listener.Addr().String()).(*socketBucketStore)
+	}
+}
+
+// waitForWaiters waits until n requests are queued for the bucket on the server.
+func waitForWaiters(t *testing.T, store *memoryBucketStore, hash string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if b := store.getBucket(hash, false); b != nil {
+			b.mu.mu.Lock()
+			waiters := len(b.mu.waiters)
+			b.mu.mu.Unlock()
+			if waiters == n {
+				return
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d waiters", n)
+}
+
+func TestSocketBucketStore_LockOrder(t *testing.T) {
+	store, newClient := serveTestBucketStore(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	holder := newClient()
+	if _, _, err := holder.Lock(ctx, "hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	type result struct {
+		priority Priority
+		bucket   Bucket
+	}
+	results := make(chan result, 3)
+	priorities := []Priority{PriorityLow, PriorityNormal, PriorityHigh}
+	for i, priority := range priorities {
+		client := newClient()
+		go func() {
+			bucket, _, err := client.Lock(withRequestPriority(ctx, priority), "hash")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			results <- result{priority: priority, bucket: bucket}
+			_ = client.Unlock("hash", nil)
+		}()
+		// queue the clients one after another, so the arrival order differs from the priority order
+		waitForWaiters(t, store, "hash", i+1)
+	}
+
+	if err := holder.Unlock("hash", func(bucket *Bucket) {
+		bucket.ID = "bucket"
+		bucket.Remaining = 5
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var order []Priority
+	for range priorities {
+		r := <-results
+		if r.bucket.ID != "bucket" || r.bucket.Remaining != 5 {
+			t.Errorf("expected the bucket updated by the previous holder, got %+v", r.bucket)
+		}
+		order = append(order, r.priority)
+	}
+	if expected := []Priority{PriorityHigh, PriorityNormal, PriorityLow}; !slices.Equal(order, expected) {
+		t.Errorf("expected lock order %v, got %v", expected, order)
+	}
+}
+
+func TestSocketBucketStore_UnlockOnDisconnect(t *testing.T) {
+	_, newClient := serveTestBucketStore(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	crashed := newClient()
+	if _, _, err := crashed.Lock(ctx, "hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// simulate a crashed process by closing its connection without unlocking
+	crashed.locksMu.Lock()
+	_ = crashed.locks["hash"].conn.Close()
+	crashed.locksMu.Unlock()
+
+	if _, _, err := newClient().Lock(ctx, "hash"); err != nil {
+		t.Fatalf("expected the bucket to be unlocked after the holder disconnected, got %v", err)
+	}
+}
+
+func TestSocketBucketStore_GlobalAndSnapshot(t *testing.T) {
+	_, newClient := serveTestBucketStore(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reset := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+	if err := newClient().SetGlobalReset(reset); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := newClient()
+	_, global, err := client.Lock(ctx, "hash")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !global.Equal(reset) {
+		t.Errorf("expected global reset %s, got %s", reset, global)
+	}
+	if err = client.Unlock("hash", func(bucket *Bucket) {
+		bucket.ID = "bucket"
+		bucket.Limit = 10
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snapshot, err := newClient().Snapshot()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !snapshot.GlobalReset.Equal(reset) {
+		t.Errorf("expected snapshot global reset %s, got %s", reset, snapshot.GlobalReset)
+	}
+	if b := snapshot.Buckets["hash"]; b.ID != "bucket" || b.Limit != 10 {
+		t.Errorf("expected updated bucket in snapshot, got %+v", b)
+	}
+}
@@ -20,10 +20,15 @@ func NewClient(botToken string, opts ...ClientConfigOpt) Client {
 	cfg := defaultClientConfig()
 	cfg.apply(opts)
 
-	return &clientImpl{
+	client := &clientImpl{
 		botToken: botToken,
 		config:   cfg,
 	}
+	if cfg.Coalescing {
+		client.coalescer = newCoalescer(cfg.CoalescingEndpoints)
+	}
+	client.doer = chainInterceptors(DoerFunc(client.do), cfg.Interceptors)
+	return client
 }
 
 // Client allows doing requests to different endpoints
@@ -37,13 +42,16 @@ type Client interface {
 	// Close closes the rest client and awaits all pending requests to finish. You can use a cancelling context to abort the waiting
 	Close(ctx context.Context)
 
-	// Do makes a request to the given CompiledAPIRoute and marshals the given any as json and unmarshalls the response into the given interface
+	// Do makes a request to the given CompiledAPIRoute and marshals the given any as json and unmarshalls the response into the given interface.
+	// If rsBody is a *[]byte, the raw response body is stored in it instead.
 	Do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error
 }
 
 type clientImpl struct {
-	botToken string
-	config   clientConfig
+	botToken  string
+	config    clientConfig
+	doer      Doer
+	coalescer *coalescer
 }
 
 func (c *clientImpl) Close(ctx context.Context) {
@@ -59,15 +67,22 @@ func (c *clientImpl) RateLimiter() RateLimiter {
 	return c.config.RateLimiter
 }
 
-func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, tries int, opts []RequestOpt) error {
+// retry does the request and retries it on 429 responses up to RateLimiter.MaxRetries times (tries)
+// and on transient errors according to the RetryPolicy (attempt).
+func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, tries int, attempt int, opts []RequestOpt) error {
 	var (
 		rawRqBody   []byte
 		err         error
 		contentType string
+		stream      *discord.MultipartStream
 	)
 
 	if rqBody != nil {
 		switch v := rqBody.(type) {
+		case *discord.MultipartStream:
+			contentType = v.ContentType
+			stream = v
+
 		case *discord.MultipartBuffer:
 			contentType = v.ContentType
 			rawRqBody = v.Buffer.Bytes()
@@ -88,6 +103,13 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 	if err != nil {
 		return err
 	}
+	if stream != nil {
+		// the body is opened right before sending, so files are only read while the request is in flight
+		rq.ContentLength = stream.Len()
+		rq.GetBody = func() (io.ReadCloser, error) {
+			return stream.Open()
+		}
+	}
 
 	rq.Header.Set("User-Agent", c.config.UserAgent)
 	if contentType != "" {
@@ -99,11 +121,23 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		opts = append([]RequestOpt{WithToken(discord.TokenTypeBot, c.botToken)}, opts...)
 	}
 
-	cfg := defaultRequestConfig(rq)
+	cfg := defaultRequestConfig(rq, c.config.RetryPolicy)
 	cfg.apply(opts)
 
+	if cfg.TokenSource != nil {
+		tokenType, token, err := cfg.TokenSource.Token(cfg.Ctx)
+		if err != nil {
+			return fmt.Errorf("error getting token from token source: %w", err)
+		}
+		rq.Header.Set("Authorization", tokenType.Apply(token))
+	}
+
 	if rqBody != nil && c.config.Logger.Enabled(cfg.Ctx, slog.LevelDebug) {
-		c.config.Logger.DebugContext(cfg.Ctx, "new request", slog.String("endpoint", endpoint.URL), slog.String("body", string(rawRqBody)))
+		body := string(rawRqBody)
+		if stream != nil {
+			body = "<multipart stream>"
+		}
+		c.config.Logger.DebugContext(cfg.Ctx, "new request", slog.String("endpoint", endpoint.URL), slog.String("body", body))
 	}
 
 	if cfg.Delay > 0 {
@@ -117,22 +151,52 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 	}
 
 	// wait for rate limits
-	err = c.RateLimiter().Wait(cfg.Ctx, endpoint)
+	waitCtx := cfg.Ctx
+	if cfg.Essential {
+		waitCtx = withEssentialRequest(waitCtx)
+	}
+	if cfg.Priority != PriorityNormal {
+		waitCtx = withRequestPriority(waitCtx, cfg.Priority)
+	}
+	waitStart := time.Now()
+	err = c.RateLimiter().Wait(waitCtx, endpoint)
+	for _, timing := range cfg.Timings {
+		timing.RateLimitWait += time.Since(waitStart)
+	}
 	if err != nil {
 		return fmt.Errorf("error locking bucket in rest client: %w", err)
 	}
 	rq = cfg.Request.WithContext(cfg.Ctx)
+	if stream != nil {
+		if rq.Body, err = stream.Open(); err != nil {
+			_ = c.RateLimiter().Unlock(endpoint, nil)
+			return fmt.Errorf("error opening multipart stream in rest client: %w", err)
+		}
+	}
 
 	for _, check := range cfg.Checks {
 		if !check() {
+			_ = rq.Body.Close()
 			_ = c.RateLimiter().Unlock(endpoint, nil)
 			return discord.ErrCheckFailed
 		}
 	}
 
+	networkStart := time.Now()
 	rs, err := c.HTTPClient().Do(rq)
+	for _, timing := range cfg.Timings {
+		timing.Attempts++
+		timing.Network += time.Since(networkStart)
+	}
 	if err != nil {
 		_ = c.RateLimiter().Unlock(endpoint, nil)
+		if cfg.RetryPolicy.ShouldRetryErr(rq.Method, attempt, err) {
+			c.config.Logger.DebugContext(cfg.Ctx, "retrying request after error", slog.String("endpoint", endpoint.URL), slog.Int("attempt", attempt), slog.Any("err", err))
+			if err = cfg.RetryPolicy.wait(cfg.Ctx, attempt+1, nil); err != nil {
+				return err
+			}
+			return c.retry(endpoint, rqBody, rsBody, tries, attempt+1, opts)
+		}
 		return fmt.Errorf("error doing request in rest client: %w", err)
 	}
 	defer func() {
@@ -145,7 +209,12 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 
 	var rawRsBody []byte
 	if rs.Body != nil {
-		if rawRsBody, err = io.ReadAll(rs.Body); err != nil {
+		readStart := time.Now()
+		rawRsBody, err = io.ReadAll(rs.Body)
+		for _, timing := range cfg.Timings {
+			timing.Network += time.Since(readStart)
+		}
+		if err != nil {
 			return fmt.Errorf("error reading response body in rest client: %w", err)
 		}
 		if c.config.Logger.Enabled(cfg.Ctx, slog.LevelDebug) {
@@ -160,6 +229,10 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 			return nil
 		}
 
+		if raw, ok := rsBody.(*[]byte); ok {
+			*raw = rawRsBody
+			return nil
+		}
 		if rsBody != nil && rs.Body != nil {
 			if err = json.Unmarshal(rawRsBody, rsBody); err != nil {
 				c.config.Logger.Error("error unmarshalling response body", slog.Any("err", err), slog.String("endpoint", endpoint.URL), slog.String("code", rs.Status), slog.String("body", string(rawRsBody)))
@@ -172,7 +245,14 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		if tries >= c.RateLimiter().MaxRetries() {
 			return newError(rq, rawRqBody, rs, rawRsBody)
 		}
-		return c.retry(endpoint, rqBody, rsBody, tries+1, opts)
+		return c.retry(endpoint, rqBody, rsBody, tries+1, attempt, opts)
+
+	case cfg.RetryPolicy.ShouldRetryStatus(rq.Method, attempt, rs.StatusCode):
+		c.config.Logger.DebugContext(cfg.Ctx, "retrying request after status", slog.String("endpoint", endpoint.URL), slog.Int("attempt", attempt), slog.String("code", rs.Status))
+		if err = cfg.RetryPolicy.wait(cfg.Ctx, attempt+1, rs); err != nil {
+			return err
+		}
+		return c.retry(endpoint, rqBody, rsBody, tries, attempt+1, opts)
 
 	default:
 		return newError(rq, rawRqBody, rs, rawRsBody)
@@ -180,5 +260,28 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 }
 
 func (c *clientImpl) Do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error {
-	return c.retry(endpoint, rqBody, rsBody, 1, opts)
+	if c.config.Validation {
+		if err := validateBody(rqBody); err != nil {
+			return err
+		}
+	}
+	return c.doer.Do(endpoint, rqBody, rsBody, opts...)
+}
+
+// validateBody validates the request body or the payload of a multipart body if it implements discord.Validator.
+func validateBody(rqBody any) error {
+	if stream, ok := rqBody.(*discord.MultipartStream); ok {
+		rqBody = stream.Value()
+	}
+	if validator, ok := rqBody.(discord.Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+func (c *clientImpl) do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error {
+	if c.coalescer != nil && rqBody == nil && c.coalescer.enabled(endpoint.Endpoint) {
+		return c.coalesce(endpoint, rsBody, opts)
+	}
+	return c.retry(endpoint, rqBody, rsBody, 1, 1, opts)
 }
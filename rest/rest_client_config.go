@@ -22,6 +22,11 @@ type clientConfig struct {
 	RateLimiterConfigOpts []RateLimiterConfigOpt
 	URL                   string
 	UserAgent             string
+	RetryPolicy           RetryPolicy
+	Interceptors          []Interceptor
+	Coalescing            bool
+	CoalescingEndpoints   []*Endpoint
+	Validation            bool
 }
 
 // ClientConfigOpt can be used to supply optional parameters to NewClient
@@ -85,3 +90,38 @@ func WithUserAgent(userAgent string) ClientConfigOpt {
 		config.UserAgent = userAgent
 	}
 }
+
+// WithRetryPolicy sets the RetryPolicy used for transient errors of all requests. See DefaultRetryPolicy for a sensible default.
+// It can be overridden per request with WithRequestRetryPolicy.
+func WithRetryPolicy(retryPolicy RetryPolicy) ClientConfigOpt {
+	return func(config *clientConfig) {
+		config.RetryPolicy = retryPolicy
+	}
+}
+
+// WithInterceptors adds Interceptor(s) which wrap every request of the rest client. The first Interceptor is the outermost one.
+func WithInterceptors(interceptors ...Interceptor) ClientConfigOpt {
+	return func(config *clientConfig) {
+		config.Interceptors = append(config.Interceptors, interceptors...)
+	}
+}
+
+// WithCoalescing deduplicates identical GET requests to the given endpoints which are in flight at the same time.
+// Only one of them is sent and its response is returned to all callers. Requests are identical if they have the same URL, query & Authorization header.
+// If no endpoints are given, requests to all GET endpoints are coalesced. It can be disabled per request with WithoutCoalescing.
+//
+//	rest.WithCoalescing(rest.GetMember, rest.GetChannel, rest.GetGuild)
+func WithCoalescing(endpoints ...*Endpoint) ClientConfigOpt {
+	return func(config *clientConfig) {
+		config.Coalescing = true
+		config.CoalescingEndpoints = append(config.CoalescingEndpoints, endpoints...)
+	}
+}
+
+// WithValidation validates request bodies implementing discord.Validator before they are sent.
+// Invalid bodies are rejected with discord.ValidationErrors instead of spending a request.
+func WithValidation() ClientConfigOpt {
+	return func(config *clientConfig) {
+		config.Validation = true
+	}
+}
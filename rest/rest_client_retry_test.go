@@ -0,0 +1,66 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/resttest"
+)
+
+func TestClient_RetryPolicy(t *testing.T) {
+	fake := resttest.NewServer()
+	defer fake.Close()
+	guildID := fake.CreateGuild("test")
+	channelID := fake.CreateChannel(guildID, discord.ChannelTypeGuildText, "general")
+
+	// the first request of each method fails with a 503 before it reaches the fake
+	var failed sync.Map
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if _, ok := failed.LoadOrStore(r.Method, true); !ok {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	policy := rest.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, StatusCodes: []int{http.StatusServiceUnavailable}}
+	client := rest.New(rest.NewClient("token", rest.WithURL(server.URL), rest.WithRetryPolicy(policy)))
+
+	if _, err := client.GetMessages(channelID, 0, 0, 0, 0); err != nil {
+		t.Errorf("expected GET to succeed after a retry, got %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests for GET, got %d", got)
+	}
+
+	requests.Store(0)
+	if _, err := client.CreateMessage(channelID, discord.MessageCreate{Content: "hello"}); err == nil {
+		t.Error("expected POST to fail without retry")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request for POST, got %d", got)
+	}
+	if len(fake.Messages(channelID)) != 0 {
+		t.Error("expected no message to be created")
+	}
+
+	// the per request policy allows retrying the POST
+	failed.Delete(http.MethodPost)
+	requests.Store(0)
+	policy.RetryNonIdempotent = true
+	if _, err := client.CreateMessage(channelID, discord.MessageCreate{Content: "hello"}, rest.WithRequestRetryPolicy(policy)); err != nil {
+		t.Errorf("expected POST to succeed after a retry, got %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests for POST, got %d", got)
+	}
+}
@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestClient_Validation(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"2","channel_id":"1"}`))
+	}))
+	defer server.Close()
+
+	channels := NewChannels(NewClient("token", WithURL(server.URL), WithRateLimiter(NewNoopRateLimiter()), WithValidation()), discord.AllowedMentions{})
+
+	tooLong := discord.NewMessageCreate().WithContent(strings.Repeat("a", 2001))
+	for _, messageCreate := range []discord.MessageCreate{
+		tooLong,
+		tooLong.AddFiles(discord.NewFile("file.txt", "", strings.NewReader("file"))),
+	} {
+		_, err := channels.CreateMessage(1, messageCreate)
+		var errs discord.ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "content" {
+			t.Errorf("expected content validation error, got %v", err)
+		}
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("expected no requests, got %d", got)
+	}
+
+	if _, err := channels.CreateMessage(1, discord.NewMessageCreate().WithContent("hello")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
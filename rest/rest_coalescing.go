@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func newCoalescer(endpoints []*Endpoint) *coalescer {
+	return &coalescer{
+		endpoints: endpoints,
+		calls:     map[string]*coalescedCall{},
+	}
+}
+
+// coalescer deduplicates identical GET requests which are in flight at the same time.
+type coalescer struct {
+	// endpoints are the endpoints which are coalesced. If empty, all GET endpoints are coalesced.
+	endpoints []*Endpoint
+
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	done   chan struct{}
+	rsBody []byte
+	err    error
+	// dups is the number of callers waiting for the call besides the one doing it
+	dups int
+}
+
+func (c *coalescer) enabled(endpoint *Endpoint) bool {
+	if endpoint.Method != http.MethodGet {
+		return false
+	}
+	return len(c.endpoints) == 0 || slices.Contains(c.endpoints, endpoint)
+}
+
+// do calls fn once for all concurrent callers with the same key and returns its result to all of them.
+// Callers waiting for another caller stop waiting when their context is done.
+// leader reports whether fn was called by this caller.
+func (c *coalescer) do(ctx context.Context, key string, fn func() ([]byte, error)) (rsBody []byte, leader bool, err error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		call.dups++
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-call.done:
+			return call.rsBody, false, call.err
+		}
+	}
+
+	call := &coalescedCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.rsBody, call.err = fn()
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.rsBody, true, call.err
+}
+
+// coalesce does the request once for all identical requests in flight and unmarshalls the shared response into rsBody.
+func (c *clientImpl) coalesce(endpoint *CompiledEndpoint, rsBody any, opts []RequestOpt) error {
+	rq, err := http.NewRequest(endpoint.Endpoint.Method, c.config.URL+endpoint.URL, nil)
+	if err != nil {
+		return err
+	}
+	keyOpts := opts
+	if endpoint.Endpoint.BotAuth {
+		keyOpts = append([]RequestOpt{WithToken(discord.TokenTypeBot, c.botToken)}, opts...)
+	}
+	cfg := defaultRequestConfig(rq, c.config.RetryPolicy)
+	cfg.apply(keyOpts)
+	// the token of a TokenSource is only known once the request is sent, so it can't be part of the key
+	if cfg.NoCoalescing || cfg.TokenSource != nil {
+		return c.retry(endpoint, nil, rsBody, 1, 1, opts)
+	}
+
+	key := rq.Method + " " + rq.URL.String() + " " + rq.Header.Get("Authorization")
+	rawRsBody, leader, err := c.coalescer.do(cfg.Ctx, key, func() ([]byte, error) {
+		var rawRsBody []byte
+		err := c.retry(endpoint, nil, &rawRsBody, 1, 1, opts)
+		return rawRsBody, err
+	})
+	if err != nil {
+		// the request we waited for was canceled by its caller, but we are still interested in the response
+		if !leader && cfg.Ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			return c.coalesce(endpoint, rsBody, opts)
+		}
+		return err
+	}
+
+	if raw, ok := rsBody.(*[]byte); ok {
+		// every caller gets its own copy as the response is shared
+		*raw = slices.Clone(rawRsBody)
+		return nil
+	}
+	if rsBody != nil && len(rawRsBody) > 0 {
+		if err = json.Unmarshal(rawRsBody, rsBody); err != nil {
+			return fmt.Errorf("error unmarshalling response body: %w", err)
+		}
+	}
+	return nil
+}
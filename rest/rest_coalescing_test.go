@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestClient_Coalescing(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","type":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", WithURL(server.URL), WithCoalescing(GetChannel), WithRateLimiter(NewNoopRateLimiter()))
+	coalescer := client.(*clientImpl).coalescer
+
+	const callers = 10
+	var wg sync.WaitGroup
+	channels := make([]discord.UnmarshalChannel, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Do(GetChannel.Compile(nil, 1), nil, &channels[i]); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	// wait until all callers wait for the same request
+	for {
+		coalescer.mu.Lock()
+		dups := 0
+		for _, call := range coalescer.calls {
+			dups = call.dups
+		}
+		coalescer.mu.Unlock()
+		if dups == callers-1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	release <- struct{}{}
+	wg.Wait()
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+	for i, channel := range channels {
+		if channel.Channel == nil || channel.Channel.ID() != 1 {
+			t.Errorf("caller %d: expected channel 1, got %v", i, channel.Channel)
+		}
+	}
+
+	// the same request with another token is not coalesced
+	requests.Store(0)
+	for _, token := range []string{"token", "other"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Do(GetChannel.Compile(nil, 1), nil, nil, WithToken(discord.TokenTypeBot, token)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	// both requests must reach the server while the other one is still in flight
+	deadline := time.Now().Add(5 * time.Second)
+	for requests.Load() != 2 {
+		if time.Now().After(deadline) {
+			t.Errorf("expected 2 concurrent requests, got %d", requests.Load())
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for range requests.Load() {
+		release <- struct{}{}
+	}
+	wg.Wait()
+}
@@ -31,6 +31,7 @@ var (
 	GetBotApplicationInfo = NewEndpoint(http.MethodGet, "/oauth2/applications/@me")
 	GetAuthorizationInfo  = NewNoBotAuthEndpoint(http.MethodGet, "/oauth2/@me")
 	Token                 = NewEndpoint(http.MethodPost, "/oauth2/token")
+	RevokeToken           = NewEndpoint(http.MethodPost, "/oauth2/token/revoke")
 )
 
 // Users
@@ -55,6 +56,11 @@ var (
 	UpdateGuild       = NewEndpoint(http.MethodPatch, "/guilds/{guild.id}")
 	GetGuildVanityURL = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/vanity-url")
 
+	GetGuildWidgetSettings = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/widget")
+	UpdateGuildWidget      = NewEndpoint(http.MethodPatch, "/guilds/{guild.id}/widget")
+	GetGuildWidget         = NewNoBotAuthEndpoint(http.MethodGet, "/guilds/{guild.id}/widget.json")
+	GetGuildWidgetImage    = NewNoBotAuthEndpoint(http.MethodGet, "/guilds/{guild.id}/widget.png")
+
 	CreateGuildChannel     = NewEndpoint(http.MethodPost, "/guilds/{guild.id}/channels")
 	GetGuildChannels       = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/channels")
 	UpdateChannelPositions = NewEndpoint(http.MethodPatch, "/guilds/{guild.id}/channels")
@@ -342,6 +348,20 @@ var (
 	GetSKUSubscription  = NewEndpoint(http.MethodGet, "/skus/{sku.id}/subscriptions/{subscription.id}")
 )
 
+// Lobbies
+var (
+	CreateLobby = NewEndpoint(http.MethodPost, "/lobbies")
+	GetLobby    = NewEndpoint(http.MethodGet, "/lobbies/{lobby.id}")
+	UpdateLobby = NewEndpoint(http.MethodPatch, "/lobbies/{lobby.id}")
+	DeleteLobby = NewEndpoint(http.MethodDelete, "/lobbies/{lobby.id}")
+
+	AddLobbyMember    = NewEndpoint(http.MethodPut, "/lobbies/{lobby.id}/members/{user.id}")
+	RemoveLobbyMember = NewEndpoint(http.MethodDelete, "/lobbies/{lobby.id}/members/{user.id}")
+	LeaveLobby        = NewNoBotAuthEndpoint(http.MethodDelete, "/lobbies/{lobby.id}/members/@me")
+
+	LinkLobbyChannel = NewNoBotAuthEndpoint(http.MethodPatch, "/lobbies/{lobby.id}/channel-linking")
+)
+
 // NewEndpoint returns a new Endpoint which requires bot auth with the given http method & route.
 func NewEndpoint(method string, route string) *Endpoint {
 	return &Endpoint{
@@ -0,0 +1,43 @@
+package rest
+
+import (
+	"time"
+)
+
+// Doer does a request to the given CompiledEndpoint, see Client.Do.
+type Doer interface {
+	Do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error
+}
+
+// DoerFunc is a function which implements Doer.
+type DoerFunc func(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error
+
+// Do calls the DoerFunc.
+func (f DoerFunc) Do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error {
+	return f(endpoint, rqBody, rsBody, opts...)
+}
+
+// Interceptor wraps the Doer of a Client to inspect, modify or short-circuit requests.
+// It receives the CompiledEndpoint, the request body, the RequestOpt(s) and the response body to unmarshal into,
+// and sees the result of the request as the error returned by the next Doer.
+//
+// Interceptors are configured with WithInterceptors and run for every request of the Client, including retries done by the caller but not retries done by the Client itself.
+type Interceptor func(next Doer) Doer
+
+// chainInterceptors wraps the doer with the interceptors, so the first interceptor is the outermost one.
+func chainInterceptors(doer Doer, interceptors []Interceptor) Doer {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		doer = interceptors[i](doer)
+	}
+	return doer
+}
+
+// RequestTiming holds where the time of a request was spent. Pass it to a request with WithRequestTiming.
+type RequestTiming struct {
+	// Attempts is the number of times the request was sent.
+	Attempts int
+	// RateLimitWait is the time spent waiting for the RateLimiter over all attempts.
+	RateLimitWait time.Duration
+	// Network is the time spent sending the request & reading the response over all attempts.
+	Network time.Duration
+}
@@ -0,0 +1,81 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/resttest"
+)
+
+func TestClient_Interceptors(t *testing.T) {
+	fake := resttest.NewServer()
+	defer fake.Close()
+	guildID := fake.CreateGuild("test")
+	channelID := fake.CreateChannel(guildID, discord.ChannelTypeGuildText, "general")
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fake.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	var calls []string
+	record := func(name string) rest.Interceptor {
+		return func(next rest.Doer) rest.Doer {
+			return rest.DoerFunc(func(endpoint *rest.CompiledEndpoint, rqBody any, rsBody any, opts ...rest.RequestOpt) error {
+				calls = append(calls, name+" before")
+				err := next.Do(endpoint, rqBody, rsBody, opts...)
+				calls = append(calls, name+" after")
+				return err
+			})
+		}
+	}
+	// answers GET messages without sending a request
+	shortCircuit := func(next rest.Doer) rest.Doer {
+		return rest.DoerFunc(func(endpoint *rest.CompiledEndpoint, rqBody any, rsBody any, opts ...rest.RequestOpt) error {
+			if endpoint.Endpoint != rest.GetMessages {
+				return next.Do(endpoint, rqBody, rsBody, opts...)
+			}
+			calls = append(calls, "short-circuit")
+			*rsBody.(*[]discord.Message) = []discord.Message{{Content: "cached"}}
+			return nil
+		})
+	}
+
+	client := rest.New(rest.NewClient("token", rest.WithURL(server.URL), rest.WithInterceptors(record("first"), record("second"), shortCircuit)))
+
+	var timing rest.RequestTiming
+	if _, err := client.CreateMessage(channelID, discord.MessageCreate{Content: "hello"}, rest.WithRequestTiming(&timing)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"first before", "second before", "second after", "first after"}; !slices.Equal(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+	if timing.Attempts != 1 || timing.Network <= 0 {
+		t.Errorf("expected timing of 1 attempt with network time, got %+v", timing)
+	}
+
+	calls = nil
+	messages, err := client.GetMessages(channelID, 0, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Content != "cached" {
+		t.Errorf("expected the short-circuited response, got %+v", messages)
+	}
+	if expected := []string{"first before", "second before", "short-circuit", "second after", "first after"}; !slices.Equal(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected the short-circuited request not to be sent, got %d requests", got)
+	}
+}
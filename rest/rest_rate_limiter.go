@@ -6,10 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
-	"sync"
 	"time"
-
-	"github.com/sasha-s/go-csync"
 )
 
 const (
@@ -17,6 +14,8 @@ const (
 	MaxRetries = 10
 	// CleanupInterval is the interval at which the rate limiter cleans up old buckets
 	CleanupInterval = time.Second * 10
+	// GlobalRequestsPerSecond is the default global rate limit of a bot
+	GlobalRequestsPerSecond = 50
 )
 
 // RateLimiter can be used to supply your own rate limit implementation
@@ -38,83 +37,111 @@ type RateLimiter interface {
 	Unlock(endpoint *CompiledEndpoint, rs *http.Response) error
 }
 
+// RateLimitEventType is the reason a RateLimitEvent was sent.
+type RateLimitEventType int
+
+const (
+	// RateLimitEventBucketExhausted is sent when a response reports no remaining requests for its bucket.
+	RateLimitEventBucketExhausted RateLimitEventType = iota
+	// RateLimitEventRouteLimited is sent when a request received a 429 response for its bucket.
+	RateLimitEventRouteLimited
+	// RateLimitEventGlobalLimited is sent when a request received a 429 response for the global rate limit.
+	RateLimitEventGlobalLimited
+	// RateLimitEventCloudflareLimited is sent when a request received a 429 response from cloudflare.
+	RateLimitEventCloudflareLimited
+)
+
+// RateLimitEvent is sent to the listeners configured with WithRateLimitListeners.
+type RateLimitEvent struct {
+	Type     RateLimitEventType
+	Endpoint *CompiledEndpoint
+	// Hash is the route hash of the bucket, see RateLimitSnapshot.Buckets
+	Hash string
+	// Bucket is the state of the bucket after the response was applied
+	Bucket Bucket
+	// RetryAfter is the time to wait as reported by a 429 response
+	RetryAfter time.Duration
+}
+
 // NewRateLimiter return a new default RateLimiter with the given RateLimiterConfigOpt(s).
 func NewRateLimiter(opts ...RateLimiterConfigOpt) RateLimiter {
 	cfg := defaultRateLimiterConfig()
 	cfg.apply(opts)
 
 	rateLimiter := &rateLimiterImpl{
-		config:  cfg,
-		buckets: map[string]*bucket{},
+		config: cfg,
+	}
+	if cfg.GlobalRequestsPerSecond > 0 {
+		rateLimiter.global = newGlobalLimiter(cfg.GlobalRequestsPerSecond)
 	}
-
-	go rateLimiter.cleanup()
 
 	return rateLimiter
 }
 
+var _ RateLimitSnapshotter = (*rateLimiterImpl)(nil)
+
 type rateLimiterImpl struct {
 	config rateLimiterConfig
 
-	// global Rate Limit
-	global time.Time
-
-	// Hash + Major Parameter -> bucket
-	buckets   map[string]*bucket
-	bucketsMu sync.Mutex
+	// global is nil if the proactive global rate limit is disabled
+	global  *globalLimiter
+	invalid invalidRequestCounter
 }
 
 func (l *rateLimiterImpl) MaxRetries() int {
 	return l.config.MaxRetries
 }
 
-func (l *rateLimiterImpl) cleanup() {
-	ticker := time.NewTicker(l.config.CleanupInterval)
-	for range ticker.C {
-		l.doCleanup()
-	}
+func (l *rateLimiterImpl) Close(ctx context.Context) {
+	l.config.BucketStore.Close(ctx)
 }
 
-func (l *rateLimiterImpl) doCleanup() {
-	l.bucketsMu.Lock()
-	defer l.bucketsMu.Unlock()
-	before := len(l.buckets)
-	now := time.Now()
-	for hash, b := range l.buckets {
-		if !b.mu.TryLock() {
-			continue
-		}
-		if b.Reset.Before(now) {
-			l.config.Logger.Debug("cleaning up bucket", slog.String("hash", hash), slog.String("id", b.ID), slog.Time("reset", b.Reset))
-			delete(l.buckets, hash)
-		}
-		b.mu.Unlock()
-	}
-	if before != len(l.buckets) {
-		l.config.Logger.Debug("cleaned up rate limit buckets", slog.Int("before", before), slog.Int("after", len(l.buckets)), slog.Int("removed", before-len(l.buckets)))
-	}
+func (l *rateLimiterImpl) Reset() {
+	l.config.BucketStore.Reset()
+	l.invalid.reset()
 }
 
-func (l *rateLimiterImpl) Close(ctx context.Context) {
-	var wg sync.WaitGroup
-	l.bucketsMu.Lock()
-	for i := range l.buckets {
-		wg.Add(1)
-		b := l.buckets[i]
-		go func() {
-			_ = b.mu.CLock(ctx)
-			wg.Done()
-		}()
+// Snapshot returns ErrSnapshotNotSupported together with the invalid request state if the BucketStore does not implement RateLimitSnapshotter.
+func (l *rateLimiterImpl) Snapshot() (RateLimitSnapshot, error) {
+	var (
+		snapshot RateLimitSnapshot
+		err      = ErrSnapshotNotSupported
+	)
+	if snapshotter, ok := l.config.BucketStore.(RateLimitSnapshotter); ok {
+		snapshot, err = snapshotter.Snapshot()
 	}
-	wg.Wait()
+	snapshot.InvalidRequests = l.invalid.count()
+	snapshot.InvalidRequestsBlocked = l.invalidRequestsBlocked(snapshot.InvalidRequests)
+	return snapshot, err
 }
 
-func (l *rateLimiterImpl) Reset() {
-	l.bucketsMu.Lock()
-	defer l.bucketsMu.Unlock()
+func (l *rateLimiterImpl) invalidRequestsBlocked(invalidRequests int) bool {
+	return l.config.InvalidRequestBlockThreshold > 0 && invalidRequests >= l.config.InvalidRequestBlockThreshold
+}
 
-	l.global = time.Time{}
-	clear(l.buckets)
+// countInvalidRequest counts 401, 403 & 429 responses as Discord does for its temporary cloudflare bans
+func (l *rateLimiterImpl) countInvalidRequest(rs *http.Response) {
+	if rs == nil {
+		return
+	}
+	switch rs.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+	case http.StatusTooManyRequests:
+		// shared rate limits do not count towards invalid requests
+		if rs.Header.Get("X-RateLimit-Scope") == "shared" {
+			return
+		}
+	default:
+		return
+	}
+
+	count := l.invalid.add()
+	if count == l.config.InvalidRequestWarnThreshold {
+		l.config.Logger.Warn("many invalid requests, you might get temporarily banned by cloudflare", slog.Int("invalid_requests", count), slog.Int("limit", InvalidRequestLimit), slog.Duration("window", InvalidRequestWindow))
+	}
+	if count == l.config.InvalidRequestBlockThreshold {
+		l.config.Logger.Error("too many invalid requests, refusing non-essential requests", slog.Int("invalid_requests", count), slog.Int("limit", InvalidRequestLimit), slog.Duration("window", InvalidRequestWindow))
+	}
 }
 
 func (l *rateLimiterImpl) getRouteHash(endpoint *CompiledEndpoint) string {
@@ -126,37 +153,17 @@ func (l *rateLimiterImpl) getRouteHash(endpoint *CompiledEndpoint) string {
 	return hash
 }
 
-func (l *rateLimiterImpl) getBucket(endpoint *CompiledEndpoint, create bool) *bucket {
-	hash := l.getRouteHash(endpoint)
-
-	l.config.Logger.Debug("locking buckets")
-	l.bucketsMu.Lock()
-	defer func() {
-		l.config.Logger.Debug("unlocking buckets")
-		l.bucketsMu.Unlock()
-	}()
-	b, ok := l.buckets[hash]
-	if !ok {
-		if !create {
-			return nil
-		}
-
-		b = &bucket{
-			Remaining: 1,
-			// we don't know the limit yet
-			Limit: -1,
-		}
-		l.buckets[hash] = b
+func (l *rateLimiterImpl) Wait(ctx context.Context, endpoint *CompiledEndpoint) error {
+	if !IsEssentialRequest(ctx) && l.invalidRequestsBlocked(l.invalid.count()) {
+		return ErrInvalidRequestLimit
 	}
-	return b
-}
 
-func (l *rateLimiterImpl) Wait(ctx context.Context, endpoint *CompiledEndpoint) error {
-	b := l.getBucket(endpoint, true)
-	l.config.Logger.Debug("locking rest bucket", slog.String("id", b.ID), slog.Int("limit", b.Limit), slog.Int("remaining", b.Remaining), slog.Time("reset", b.Reset))
-	if err := b.mu.CLock(ctx); err != nil {
+	hash := l.getRouteHash(endpoint)
+	b, global, err := l.config.BucketStore.Lock(ctx, hash)
+	if err != nil {
 		return err
 	}
+	l.config.Logger.Debug("locked rest bucket", slog.String("id", b.ID), slog.Int("limit", b.Limit), slog.Int("remaining", b.Remaining), slog.Time("reset", b.Reset))
 
 	var until time.Time
 	now := time.Now()
@@ -164,49 +171,79 @@ func (l *rateLimiterImpl) Wait(ctx context.Context, endpoint *CompiledEndpoint)
 	if b.Remaining == 0 && b.Reset.After(now) {
 		until = b.Reset
 	} else {
-		until = l.global
+		until = global
 	}
 
 	if until.After(now) {
 		// TODO: do we want to return early when we know the rate limit bigger than ctx deadline?
 		if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
-			b.mu.Unlock()
+			_ = l.config.BucketStore.Unlock(hash, nil)
 			return context.DeadlineExceeded
 		}
 
 		select {
 		case <-ctx.Done():
-			b.mu.Unlock()
+			_ = l.config.BucketStore.Unlock(hash, nil)
 			return ctx.Err()
 		case <-time.After(until.Sub(now)):
 		}
 	}
+
+	// interaction & webhook requests are not bound to the global rate limit of the bot
+	if l.global != nil && endpoint.Endpoint.BotAuth {
+		if err = l.global.wait(ctx); err != nil {
+			_ = l.config.BucketStore.Unlock(hash, nil)
+			return err
+		}
+	}
 	return nil
 }
 
 func (l *rateLimiterImpl) Unlock(endpoint *CompiledEndpoint, rs *http.Response) error {
-	b := l.getBucket(endpoint, false)
-	if b == nil {
-		return nil
+	hash := l.getRouteHash(endpoint)
+	l.countInvalidRequest(rs)
+	update, event, err := l.parseHeaders(endpoint, rs)
+
+	var bucket Bucket
+	var storeUpdate func(bucket *Bucket)
+	if update != nil {
+		storeUpdate = func(b *Bucket) {
+			update(b)
+			bucket = *b
+		}
+	}
+	if unlockErr := l.config.BucketStore.Unlock(hash, storeUpdate); unlockErr != nil {
+		return unlockErr
 	}
-	defer func() {
-		l.config.Logger.Debug("unlocking rest bucket", slog.String("id", b.ID), slog.Int("limit", b.Limit), slog.Int("remaining", b.Remaining), slog.Time("reset", b.Reset))
-		b.mu.Unlock()
-	}()
 
+	if event == nil && update != nil && bucket.Remaining == 0 {
+		event = &RateLimitEvent{Type: RateLimitEventBucketExhausted}
+	}
+	if event != nil && len(l.config.Listeners) > 0 {
+		event.Endpoint = endpoint
+		event.Hash = hash
+		event.Bucket = bucket
+		for _, listener := range l.config.Listeners {
+			listener(*event)
+		}
+	}
+	return err
+}
+
+// parseHeaders parses the rate limit headers of the response and returns a function which applies them to the bucket
+// and the RateLimitEvent of a 429 response. Global rate limits are applied to the BucketStore directly.
+func (l *rateLimiterImpl) parseHeaders(endpoint *CompiledEndpoint, rs *http.Response) (func(bucket *Bucket), *RateLimitEvent, error) {
 	// no response provided means we can't update anything and just unlock it
 	if rs == nil || rs.Header == nil {
-		return nil
+		return nil, nil, nil
 	}
 	bucketHeader := rs.Header.Get("X-RateLimit-Bucket")
 
 	// if we don't have a bucket header, we can't update anything
 	if bucketHeader == "" {
-		return nil
+		return nil, nil, nil
 	}
 
-	b.ID = bucketHeader
-
 	global := rs.Header.Get("X-RateLimit-Global") != ""
 	cloudflare := rs.Header.Get("via") == ""
 	remainingHeader := rs.Header.Get("X-RateLimit-Remaining")
@@ -217,69 +254,82 @@ func (l *rateLimiterImpl) Unlock(endpoint *CompiledEndpoint, rs *http.Response)
 
 	l.config.Logger.Debug("ratelimit response headers", slog.Int("code", rs.StatusCode), slog.Bool("global", global), slog.Bool("cloudflare", cloudflare), slog.String("remaining", remainingHeader), slog.String("limit", limitHeader), slog.String("reset", resetHeader), slog.String("reset_after", resetAfterHeader), slog.String("retry_after", retryAfterHeader))
 
+	setID := func(bucket *Bucket) {
+		bucket.ID = bucketHeader
+	}
+
 	// we hit a rate limit. let's see if it was global cloudflare or a route specific one
 	if rs.StatusCode == http.StatusTooManyRequests {
 		retryAfter, err := strconv.Atoi(retryAfterHeader)
 		if err != nil {
-			return fmt.Errorf("invalid retryAfter %s: %w", retryAfterHeader, err)
+			return setID, nil, fmt.Errorf("invalid retryAfter %s: %w", retryAfterHeader, err)
 		}
-		reset := time.Now().Add(time.Second * time.Duration(retryAfter))
+		event := &RateLimitEvent{RetryAfter: time.Second * time.Duration(retryAfter)}
+		reset := time.Now().Add(event.RetryAfter)
 		if global {
-			l.global = reset
 			l.config.Logger.Warn("global rate limit exceeded", slog.Int("retry_after", retryAfter))
+			event.Type = RateLimitEventGlobalLimited
+			return setID, event, l.config.BucketStore.SetGlobalReset(reset)
 		} else if cloudflare {
-			l.global = reset
 			l.config.Logger.Warn("cloudflare rate limit exceeded", slog.Int("retry_after", retryAfter))
-		} else {
-			b.Remaining = 0
-			b.Reset = reset
-			l.config.Logger.Warn("rate limit exceeded", slog.String("endpoint", endpoint.URL), slog.Int("retry_after", retryAfter))
+			event.Type = RateLimitEventCloudflareLimited
+			return setID, event, l.config.BucketStore.SetGlobalReset(reset)
 		}
-		return nil
+		l.config.Logger.Warn("rate limit exceeded", slog.String("endpoint", endpoint.URL), slog.Int("retry_after", retryAfter))
+		event.Type = RateLimitEventRouteLimited
+		return func(bucket *Bucket) {
+			bucket.ID = bucketHeader
+			bucket.Remaining = 0
+			bucket.Reset = reset
+		}, event, nil
 	}
 
+	limit := -1
 	if limitHeader != "" {
-		limit, err := strconv.Atoi(limitHeader)
-		if err != nil {
-			return fmt.Errorf("invalid limit %s: %w", limitHeader, err)
+		var err error
+		if limit, err = strconv.Atoi(limitHeader); err != nil {
+			return setID, nil, fmt.Errorf("invalid limit %s: %w", limitHeader, err)
 		}
-		b.Limit = limit
 	}
 
+	remaining := -1
 	if remainingHeader != "" {
-		remaining, err := strconv.Atoi(remainingHeader)
-		if err != nil {
-			return fmt.Errorf("invalid remaining %s: %w", remainingHeader, err)
+		var err error
+		if remaining, err = strconv.Atoi(remainingHeader); err != nil {
+			return setID, nil, fmt.Errorf("invalid remaining %s: %w", remainingHeader, err)
 		}
-		b.Remaining = remaining
 	}
 
+	var reset time.Time
 	// we prioritize the reset after header over the reset header as it's more accurate due to clock differences
 	if resetAfterHeader != "" {
 		resetAfter, err := strconv.ParseFloat(resetAfterHeader, 64)
 		if err != nil {
-			return fmt.Errorf("invalid reset after %s: %w", resetAfterHeader, err)
+			return setID, nil, fmt.Errorf("invalid reset after %s: %w", resetAfterHeader, err)
 		}
 
-		b.Reset = time.Now().Add(time.Duration(resetAfter) * time.Second)
+		reset = time.Now().Add(time.Duration(resetAfter * float64(time.Second)))
 	} else if resetHeader != "" {
-		reset, err := strconv.ParseFloat(resetHeader, 64)
+		resetUnix, err := strconv.ParseFloat(resetHeader, 64)
 		if err != nil {
-			return fmt.Errorf("invalid reset %s: %w", resetHeader, err)
+			return setID, nil, fmt.Errorf("invalid reset %s: %w", resetHeader, err)
 		}
 
-		sec := int64(reset)
-		b.Reset = time.Unix(sec, int64((reset-float64(sec))*float64(time.Second)))
+		sec := int64(resetUnix)
+		reset = time.Unix(sec, int64((resetUnix-float64(sec))*float64(time.Second)))
 	} else {
-		return fmt.Errorf("no reset or reset after header found in response")
+		return setID, nil, fmt.Errorf("no reset or reset after header found in response")
 	}
-	return nil
-}
 
-type bucket struct {
-	mu        csync.Mutex
-	ID        string
-	Reset     time.Time
-	Remaining int
-	Limit     int
+	return func(bucket *Bucket) {
+		bucket.ID = bucketHeader
+		if limit != -1 {
+			bucket.Limit = limit
+		}
+		if remaining != -1 {
+			bucket.Remaining = remaining
+		}
+		bucket.Reset = reset
+		l.config.Logger.Debug("updated rest bucket", slog.String("id", bucket.ID), slog.Int("limit", bucket.Limit), slog.Int("remaining", bucket.Remaining), slog.Time("reset", bucket.Reset))
+	}, nil, nil
 }
@@ -7,9 +7,10 @@ import (
 
 func defaultRateLimiterConfig() rateLimiterConfig {
 	return rateLimiterConfig{
-		Logger:          slog.Default(),
-		MaxRetries:      MaxRetries,
-		CleanupInterval: CleanupInterval,
+		Logger:                      slog.Default(),
+		MaxRetries:                  MaxRetries,
+		CleanupInterval:             CleanupInterval,
+		InvalidRequestWarnThreshold: InvalidRequestLimit / 2,
 	}
 }
 
@@ -17,6 +18,12 @@ type rateLimiterConfig struct {
 	Logger          *slog.Logger
 	MaxRetries      int
 	CleanupInterval time.Duration
+	BucketStore     BucketStore
+	Listeners       []func(event RateLimitEvent)
+
+	GlobalRequestsPerSecond      int
+	InvalidRequestWarnThreshold  int
+	InvalidRequestBlockThreshold int
 }
 
 // RateLimiterConfigOpt can be used to supply optional parameters to NewRateLimiter.
@@ -27,6 +34,9 @@ func (c *rateLimiterConfig) apply(opts []RateLimiterConfigOpt) {
 		opt(c)
 	}
 	c.Logger = c.Logger.With(slog.String("name", "rest_rate_limiter"))
+	if c.BucketStore == nil {
+		c.BucketStore = NewMemoryBucketStore(c.Logger, c.CleanupInterval)
+	}
 }
 
 // WithRateLimiterLogger applies a custom logger to the rest rate limiter.
@@ -49,3 +59,38 @@ func WithCleanupInterval(cleanupInterval time.Duration) RateLimiterConfigOpt {
 		config.CleanupInterval = cleanupInterval
 	}
 }
+
+// WithBucketStore tells the rest rate limiter to keep its buckets in the given BucketStore instead of process memory.
+// Use this to share one rate limit state between multiple processes using the same token.
+// WithCleanupInterval has no effect when a custom BucketStore is used.
+func WithBucketStore(bucketStore BucketStore) RateLimiterConfigOpt {
+	return func(config *rateLimiterConfig) {
+		config.BucketStore = bucketStore
+	}
+}
+
+// WithRateLimitListeners adds listeners which are called when a bucket is exhausted or a 429 response is received.
+// Listeners are called synchronously after the bucket has been unlocked, so they should not block.
+func WithRateLimitListeners(listeners ...func(event RateLimitEvent)) RateLimiterConfigOpt {
+	return func(config *rateLimiterConfig) {
+		config.Listeners = append(config.Listeners, listeners...)
+	}
+}
+
+// WithGlobalRequestsPerSecond tells the rest rate limiter to proactively space out bot authenticated requests to stay below the given number of requests per second,
+// instead of only respecting the global rate limit after receiving a 429 response. Use GlobalRequestsPerSecond for Discord's default limit. 0 disables it, which is the default.
+func WithGlobalRequestsPerSecond(requestsPerSecond int) RateLimiterConfigOpt {
+	return func(config *rateLimiterConfig) {
+		config.GlobalRequestsPerSecond = requestsPerSecond
+	}
+}
+
+// WithInvalidRequestThresholds sets after how many invalid (401, 403 & 429) requests in InvalidRequestWindow the rest rate limiter logs a warning
+// and starts refusing requests not marked with WithEssential. 0 disables the respective threshold.
+// By default, it warns at 50% of InvalidRequestLimit and never blocks. A block threshold of 90% of InvalidRequestLimit is a sensible value.
+func WithInvalidRequestThresholds(warn int, block int) RateLimiterConfigOpt {
+	return func(config *rateLimiterConfig) {
+		config.InvalidRequestWarnThreshold = warn
+		config.InvalidRequestBlockThreshold = block
+	}
+}
@@ -0,0 +1,131 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// InvalidRequestWindow is the time window in which Discord counts invalid requests before temporarily banning the IP.
+	InvalidRequestWindow = 10 * time.Minute
+	// InvalidRequestLimit is the number of invalid (401, 403 & 429) requests in InvalidRequestWindow after which Discord temporarily bans the IP.
+	InvalidRequestLimit = 10_000
+)
+
+// ErrInvalidRequestLimit is returned by RateLimiter.Wait for non-essential requests while too many invalid requests have been made recently.
+// See WithInvalidRequestThresholds & WithEssential.
+var ErrInvalidRequestLimit = errors.New("too many invalid requests, refusing non-essential request to avoid a cloudflare ban")
+
+type essentialCtxKey struct{}
+
+// IsEssentialRequest returns whether the request with the given context was marked with WithEssential.
+// This can be used by custom RateLimiter(s).
+func IsEssentialRequest(ctx context.Context) bool {
+	essential, _ := ctx.Value(essentialCtxKey{}).(bool)
+	return essential
+}
+
+func withEssentialRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, essentialCtxKey{}, true)
+}
+
+// globalLimiter is a token bucket which spaces out requests to stay below the global rate limit.
+type globalLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newGlobalLimiter(requestsPerSecond int) *globalLimiter {
+	return &globalLimiter{
+		rate:   float64(requestsPerSecond),
+		tokens: float64(requestsPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is available.
+func (g *globalLimiter) reserve() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	g.tokens = min(g.rate, g.tokens+now.Sub(g.last).Seconds()*g.rate)
+	g.last = now
+	g.tokens--
+	if g.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-g.tokens / g.rate * float64(time.Second))
+}
+
+// cancel gives back a token which was reserved but not used.
+func (g *globalLimiter) cancel() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.tokens = min(g.rate, g.tokens+1)
+}
+
+// wait waits until a token is available or the context is done.
+func (g *globalLimiter) wait(ctx context.Context) error {
+	delay := g.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		g.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// invalidRequestCounter counts the invalid requests of the last InvalidRequestWindow.
+type invalidRequestCounter struct {
+	mu sync.Mutex
+	// times holds the time of every invalid request in the window, oldest first
+	times []time.Time
+}
+
+// add records an invalid request and returns the number of invalid requests in the window.
+func (c *invalidRequestCounter) add() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.prune(now)
+	c.times = append(c.times, now)
+	return len(c.times)
+}
+
+// count returns the number of invalid requests in the window.
+func (c *invalidRequestCounter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prune(time.Now())
+	return len(c.times)
+}
+
+func (c *invalidRequestCounter) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.times = nil
+}
+
+func (c *invalidRequestCounter) prune(now time.Time) {
+	i := 0
+	for i < len(c.times) && now.Sub(c.times[i]) > InvalidRequestWindow {
+		i++
+	}
+	if i > 0 {
+		c.times = append(c.times[:0], c.times[i:]...)
+	}
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGlobalLimiter(t *testing.T) {
+	g := newGlobalLimiter(2)
+	if g.reserve() != 0 || g.reserve() != 0 {
+		t.Fatal("expected the first 2 requests not to wait")
+	}
+	if delay := g.reserve(); delay <= 400*time.Millisecond || delay > 500*time.Millisecond {
+		t.Errorf("expected the 3rd request to wait about 500ms, got %s", delay)
+	}
+	g.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := g.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected wait to stop when the context is done, got %v", err)
+	}
+}
+
+func TestRateLimiter_GlobalRequestsPerSecond(t *testing.T) {
+	l := NewRateLimiter(WithGlobalRequestsPerSecond(1))
+	defer l.Close(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	wait := func(endpoint *CompiledEndpoint) error {
+		if err := l.Wait(ctx, endpoint); err != nil {
+			return err
+		}
+		return l.Unlock(endpoint, nil)
+	}
+
+	if err := wait(GetCurrentUser.Compile(nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// interaction callbacks are not bot authenticated and not bound to the global rate limit
+	if err := wait(CreateInteractionResponse.Compile(nil, 1, "token")); err != nil {
+		t.Fatalf("expected request without bot auth not to wait, got %v", err)
+	}
+	if err := wait(GetCurrentUser.Compile(nil)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected second bot request to wait for the global rate limit, got %v", err)
+	}
+}
+
+func TestInvalidRequestCounter(t *testing.T) {
+	var c invalidRequestCounter
+	c.times = []time.Time{
+		time.Now().Add(-InvalidRequestWindow - time.Minute),
+		time.Now().Add(-InvalidRequestWindow + time.Minute),
+	}
+	if got := c.count(); got != 1 {
+		t.Errorf("expected invalid requests outside the window to expire, got %d", got)
+	}
+	if got := c.add(); got != 2 {
+		t.Errorf("expected 2 invalid requests, got %d", got)
+	}
+	c.reset()
+	if got := c.count(); got != 0 {
+		t.Errorf("expected no invalid requests after reset, got %d", got)
+	}
+}
+
+func TestRateLimiter_InvalidRequestThresholds(t *testing.T) {
+	respond := func(l RateLimiter, statusCode int, header http.Header) {
+		t.Helper()
+		endpoint := GetCurrentUser.Compile(nil)
+		if err := l.Wait(context.Background(), endpoint); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_ = l.Unlock(endpoint, &http.Response{StatusCode: statusCode, Header: header})
+	}
+
+	l := NewRateLimiter()
+	defer l.Close(context.Background())
+	for range 3 {
+		respond(l, http.StatusForbidden, http.Header{})
+	}
+	if err := l.Wait(context.Background(), GetCurrentUser.Compile(nil)); err != nil {
+		t.Fatalf("expected requests not to be blocked by default, got %v", err)
+	}
+	_ = l.Unlock(GetCurrentUser.Compile(nil), nil)
+
+	l = NewRateLimiter(WithInvalidRequestThresholds(0, 2))
+	defer l.Close(context.Background())
+	// shared rate limits don't count as invalid requests
+	respond(l, http.StatusTooManyRequests, http.Header{"X-Ratelimit-Scope": {"shared"}})
+	respond(l, http.StatusUnauthorized, http.Header{})
+	if err := l.Wait(context.Background(), GetCurrentUser.Compile(nil)); err != nil {
+		t.Fatalf("expected requests below the threshold not to be blocked, got %v", err)
+	}
+	_ = l.Unlock(GetCurrentUser.Compile(nil), &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}})
+
+	if err := l.Wait(context.Background(), GetCurrentUser.Compile(nil)); !errors.Is(err, ErrInvalidRequestLimit) {
+		t.Errorf("expected ErrInvalidRequestLimit, got %v", err)
+	}
+	if err := l.Wait(withEssentialRequest(context.Background()), GetCurrentUser.Compile(nil)); err != nil {
+		t.Fatalf("expected essential request not to be blocked, got %v", err)
+	}
+	_ = l.Unlock(GetCurrentUser.Compile(nil), nil)
+}
@@ -5,7 +5,10 @@ import (
 	"net/http"
 )
 
-var _ RateLimiter = (*noopRateLimiter)(nil)
+var (
+	_ RateLimiter          = (*noopRateLimiter)(nil)
+	_ RateLimitSnapshotter = (*noopRateLimiter)(nil)
+)
 
 // NewNoopRateLimiter return a new noop RateLimiter.
 func NewNoopRateLimiter() RateLimiter {
@@ -23,3 +26,5 @@ func (l *noopRateLimiter) Reset() {}
 func (l *noopRateLimiter) Wait(_ context.Context, _ *CompiledEndpoint) error { return nil }
 
 func (l *noopRateLimiter) Unlock(_ *CompiledEndpoint, _ *http.Response) error { return nil }
+
+func (l *noopRateLimiter) Snapshot() (RateLimitSnapshot, error) { return RateLimitSnapshot{}, nil }
@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Priority decides in which order requests waiting for the same rate limit bucket are sent.
+// Requests with a higher priority are sent first. To avoid starvation, a waiting request gains one priority level
+// for every PriorityAging requests which were sent before it although it was queued earlier.
+type Priority int
+
+// All Priority(s) a request can have. Requests without WithPriority use PriorityNormal.
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+// PriorityAging is the number of requests which can overtake a waiting request before it gains one priority level.
+const PriorityAging = 8
+
+type priorityCtxKey struct{}
+
+// RequestPriority returns the Priority of the request with the given context, see WithPriority.
+// This can be used by custom RateLimiter(s) & BucketStore(s).
+func RequestPriority(ctx context.Context) Priority {
+	priority, _ := ctx.Value(priorityCtxKey{}).(Priority)
+	return priority
+}
+
+func withRequestPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityCtxKey{}, priority)
+}
+
+// priorityMutex is a mutex which hands the lock to the waiter with the highest Priority, see Priority.
+// Waiters with the same Priority are served in order of arrival.
+type priorityMutex struct {
+	mu      sync.Mutex
+	locked  bool
+	waiters []*priorityWaiter
+}
+
+type priorityWaiter struct {
+	priority Priority
+	// overtaken is the number of waiters which were served before this one although they were queued later
+	overtaken int
+	ready     chan struct{}
+}
+
+func (w *priorityWaiter) effectivePriority() int {
+	return int(w.priority) + w.overtaken/PriorityAging
+}
+
+// Lock waits until the mutex is handed to this caller or the context is done.
+func (m *priorityMutex) Lock(ctx context.Context, priority Priority) error {
+	m.mu.Lock()
+	if !m.locked {
+		m.locked = true
+		m.mu.Unlock()
+		return nil
+	}
+	w := &priorityWaiter{
+		priority: priority,
+		ready:    make(chan struct{}),
+	}
+	m.waiters = append(m.waiters, w)
+	m.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		select {
+		case <-w.ready:
+			// the mutex was handed to us in the meantime, pass it on
+			m.handOff()
+		default:
+			m.waiters = slices.DeleteFunc(m.waiters, func(other *priorityWaiter) bool {
+				return other == w
+			})
+		}
+		return ctx.Err()
+	}
+}
+
+// TryLock locks the mutex if it is not locked and returns whether it did.
+func (m *priorityMutex) TryLock() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locked {
+		return false
+	}
+	m.locked = true
+	return true
+}
+
+// Unlock hands the mutex to the next waiter or unlocks it if no one is waiting.
+func (m *priorityMutex) Unlock() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handOff()
+}
+
+func (m *priorityMutex) handOff() {
+	if len(m.waiters) == 0 {
+		m.locked = false
+		return
+	}
+
+	next := 0
+	for i, w := range m.waiters {
+		if w.effectivePriority() > m.waiters[next].effectivePriority() {
+			next = i
+		}
+	}
+	// everyone queued before the chosen waiter was overtaken
+	for _, w := range m.waiters[:next] {
+		w.overtaken++
+	}
+
+	w := m.waiters[next]
+	m.waiters = slices.Delete(m.waiters, next, next+1)
+	close(w.ready)
+}
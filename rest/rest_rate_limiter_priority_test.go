@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// lockOrder queues a waiter for every priority on a locked priorityMutex and returns the order in which they got the lock.
+func lockOrder(t *testing.T, priorities []Priority) []int {
+	t.Helper()
+	var m priorityMutex
+	_ = m.Lock(context.Background(), PriorityNormal)
+
+	order := make(chan int, len(priorities))
+	for i, priority := range priorities {
+		go func() {
+			if err := m.Lock(context.Background(), priority); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			order <- i
+			m.Unlock()
+		}()
+		// wait until the waiter is queued, so they arrive in order
+		for {
+			m.mu.Lock()
+			queued := len(m.waiters) == i+1
+			m.mu.Unlock()
+			if queued {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	m.Unlock()
+
+	result := make([]int, 0, len(priorities))
+	for range priorities {
+		result = append(result, <-order)
+	}
+	return result
+}
+
+func TestPriorityMutex(t *testing.T) {
+	order := lockOrder(t, []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityNormal})
+	if expected := []int{2, 1, 3, 0}; !slices.Equal(order, expected) {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+}
+
+func TestPriorityMutex_Aging(t *testing.T) {
+	priorities := []Priority{PriorityLow}
+	for range PriorityAging * 3 {
+		priorities = append(priorities, PriorityHigh)
+	}
+
+	// the low priority waiter catches up with the high priority ones after being overtaken two levels worth of times
+	order := lockOrder(t, priorities)
+	if i := slices.Index(order, 0); i != PriorityAging*2 {
+		t.Errorf("expected the low priority waiter to be served at position %d, got %d", PriorityAging*2, i)
+	}
+}
+
+func TestPriorityMutex_Cancel(t *testing.T) {
+	var m priorityMutex
+	_ = m.Lock(context.Background(), PriorityNormal)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := m.Lock(ctx, PriorityHigh); err == nil {
+		t.Fatal("expected an error when the context is done")
+	}
+	m.Unlock()
+	if !m.TryLock() {
+		t.Error("expected the mutex to be unlocked after the canceled waiter left")
+	}
+}
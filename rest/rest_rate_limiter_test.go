@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_FractionalResetAfter(t *testing.T) {
+	l := NewRateLimiter()
+	defer l.Close(context.Background())
+
+	endpoint := GetCurrentUser.Compile(nil)
+	if err := l.Wait(context.Background(), endpoint); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	header := http.Header{}
+	header.Set("X-RateLimit-Bucket", "bucket")
+	header.Set("X-RateLimit-Limit", "1")
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset-After", "0.25")
+	header.Set("Via", "1.1 google")
+	if err := l.Unlock(endpoint, &http.Response{StatusCode: http.StatusOK, Header: header}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snapshot, err := l.(RateLimitSnapshotter).Snapshot()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resetAfter := time.Until(snapshot.Buckets["GET+/users/@me"].Reset)
+	if resetAfter < 200*time.Millisecond || resetAfter > 250*time.Millisecond {
+		t.Errorf("expected reset after about 250ms, got %s", resetAfter)
+	}
+}
+
+func TestRateLimiter_Listeners(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		header     map[string]string
+		expected   *RateLimitEvent
+	}{
+		{
+			name:       "remaining requests",
+			statusCode: http.StatusOK,
+			header:     map[string]string{"X-RateLimit-Remaining": "1"},
+		},
+		{
+			name:       "bucket exhausted",
+			statusCode: http.StatusOK,
+			header:     map[string]string{"X-RateLimit-Remaining": "0"},
+			expected:   &RateLimitEvent{Type: RateLimitEventBucketExhausted},
+		},
+		{
+			name:       "route limited",
+			statusCode: http.StatusTooManyRequests,
+			header:     map[string]string{"Retry-After": "2"},
+			expected:   &RateLimitEvent{Type: RateLimitEventRouteLimited, RetryAfter: 2 * time.Second},
+		},
+		{
+			name:       "global limited",
+			statusCode: http.StatusTooManyRequests,
+			header:     map[string]string{"Retry-After": "3", "X-RateLimit-Global": "true"},
+			expected:   &RateLimitEvent{Type: RateLimitEventGlobalLimited, RetryAfter: 3 * time.Second},
+		},
+		{
+			name:       "cloudflare limited",
+			statusCode: http.StatusTooManyRequests,
+			header:     map[string]string{"Retry-After": "4", "Via": ""},
+			expected:   &RateLimitEvent{Type: RateLimitEventCloudflareLimited, RetryAfter: 4 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []RateLimitEvent
+			l := NewRateLimiter(WithRateLimitListeners(func(event RateLimitEvent) {
+				events = append(events, event)
+			}))
+			defer l.Close(context.Background())
+
+			header := http.Header{}
+			header.Set("X-RateLimit-Bucket", "bucket")
+			header.Set("X-RateLimit-Limit", "5")
+			header.Set("X-RateLimit-Reset-After", "1")
+			header.Set("Via", "1.1 google")
+			for k, v := range tt.header {
+				if v == "" {
+					header.Del(k)
+					continue
+				}
+				header.Set(k, v)
+			}
+
+			endpoint := GetCurrentUser.Compile(nil)
+			if err := l.Wait(context.Background(), endpoint); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := l.Unlock(endpoint, &http.Response{StatusCode: tt.statusCode, Header: header}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.expected == nil {
+				if len(events) != 0 {
+					t.Errorf("expected no events, got %+v", events)
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %+v", events)
+			}
+			event := events[0]
+			if event.Type != tt.expected.Type || event.RetryAfter != tt.expected.RetryAfter {
+				t.Errorf("expected event type %d with retry after %s, got type %d with retry after %s", tt.expected.Type, tt.expected.RetryAfter, event.Type, event.RetryAfter)
+			}
+			if event.Endpoint != endpoint || event.Hash != "GET+/users/@me" || event.Bucket.ID != "bucket" {
+				t.Errorf("expected event for the unlocked bucket, got %+v", event)
+			}
+		})
+	}
+}
+
+// bucketStoreWithoutSnapshot hides the Snapshot method of the wrapped BucketStore.
+type bucketStoreWithoutSnapshot struct {
+	BucketStore
+}
+
+func TestRateLimiter_SnapshotNotSupported(t *testing.T) {
+	l := NewRateLimiter(WithBucketStore(bucketStoreWithoutSnapshot{BucketStore: NewMemoryBucketStore(slog.Default(), time.Minute)}))
+	defer l.Close(context.Background())
+
+	endpoint := GetCurrentUser.Compile(nil)
+	if err := l.Wait(context.Background(), endpoint); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = l.Unlock(endpoint, &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}})
+
+	snapshot, err := l.(RateLimitSnapshotter).Snapshot()
+	if !errors.Is(err, ErrSnapshotNotSupported) {
+		t.Errorf("expected ErrSnapshotNotSupported, got %v", err)
+	}
+	if snapshot.InvalidRequests != 1 {
+		t.Errorf("expected 1 invalid request in the snapshot, got %d", snapshot.InvalidRequests)
+	}
+}
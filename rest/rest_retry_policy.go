@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryPolicy returns a RetryPolicy which retries transient server errors & network errors up to 3 times with exponential backoff.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+		StatusCodes: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// RetryPolicy decides whether a failed request is retried and how long to wait before the next attempt.
+// 429 responses are not handled by the RetryPolicy but by the RateLimiter, see RateLimiter.MaxRetries.
+// The zero value never retries.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one. Values of 1 or less disable retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the second attempt. It is doubled for every further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts. 0 means no cap.
+	MaxDelay time.Duration
+	// Jitter randomizes each delay by up to the given fraction in both directions, e.g. 0.2 means ±20%.
+	Jitter float64
+	// StatusCodes are the response status codes which are retried.
+	StatusCodes []int
+	// RetryableErr decides whether an error returned by the http.Client is retried.
+	// If nil, all errors except context cancellations are retried.
+	RetryableErr func(err error) bool
+	// RetryNonIdempotent allows retrying POST & PATCH requests after they might have reached Discord.
+	// Requests which failed to connect are always safe to retry.
+	RetryNonIdempotent bool
+}
+
+// Delay returns the backoff before the given attempt, where attempt 2 is the first retry.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 2; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// ShouldRetryStatus returns whether a request with the given method which received the given status code should be attempted again.
+func (p RetryPolicy) ShouldRetryStatus(method string, attempt int, statusCode int) bool {
+	if attempt >= p.MaxAttempts || !slices.Contains(p.StatusCodes, statusCode) {
+		return false
+	}
+	return p.RetryNonIdempotent || isIdempotent(method)
+}
+
+// ShouldRetryErr returns whether a request with the given method which failed with the given error should be attempted again.
+func (p RetryPolicy) ShouldRetryErr(method string, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if p.RetryableErr != nil && !p.RetryableErr(err) {
+		return false
+	}
+	return p.RetryNonIdempotent || isIdempotent(method) || isDialErr(err)
+}
+
+// wait waits for the backoff of the given attempt or the Retry-After header of the response, whichever is longer.
+func (p RetryPolicy) wait(ctx context.Context, attempt int, rs *http.Response) error {
+	delay := p.Delay(attempt)
+	if rs != nil {
+		if retryAfter, err := strconv.Atoi(rs.Header.Get("Retry-After")); err == nil && time.Duration(retryAfter)*time.Second > delay {
+			delay = time.Duration(retryAfter) * time.Second
+		}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isDialErr returns whether the error happened while connecting, which means the request was never sent.
+func isDialErr(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_ShouldRetryStatus(t *testing.T) {
+	p := DefaultRetryPolicy()
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		method     string
+		attempt    int
+		statusCode int
+		want       bool
+	}{
+		{"get 503", p, http.MethodGet, 1, http.StatusServiceUnavailable, true},
+		{"delete 500", p, http.MethodDelete, 2, http.StatusInternalServerError, true},
+		{"last attempt", p, http.MethodGet, 3, http.StatusServiceUnavailable, false},
+		{"client error", p, http.MethodGet, 1, http.StatusBadRequest, false},
+		{"post 503", p, http.MethodPost, 1, http.StatusServiceUnavailable, false},
+		{"patch 503", p, http.MethodPatch, 1, http.StatusServiceUnavailable, false},
+		{"post 503 non idempotent", RetryPolicy{MaxAttempts: 3, StatusCodes: p.StatusCodes, RetryNonIdempotent: true}, http.MethodPost, 1, http.StatusServiceUnavailable, true},
+		{"zero value", RetryPolicy{}, http.MethodGet, 1, http.StatusServiceUnavailable, false},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.ShouldRetryStatus(tt.method, tt.attempt, tt.statusCode); got != tt.want {
+			t.Errorf("%s: expected %t, got %t", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRetryPolicy_ShouldRetryErr(t *testing.T) {
+	p := DefaultRetryPolicy()
+	dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Err: errors.New("connection reset")}
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		method  string
+		attempt int
+		err     error
+		want    bool
+	}{
+		{"get read error", p, http.MethodGet, 1, readErr, true},
+		{"post read error", p, http.MethodPost, 1, readErr, false},
+		{"post dial error", p, http.MethodPost, 1, dialErr, true},
+		{"canceled", p, http.MethodGet, 1, context.Canceled, false},
+		{"deadline exceeded", p, http.MethodGet, 1, context.DeadlineExceeded, false},
+		{"last attempt", p, http.MethodGet, 3, readErr, false},
+		{"not retryable", RetryPolicy{MaxAttempts: 3, RetryableErr: func(error) bool { return false }}, http.MethodGet, 1, readErr, false},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.ShouldRetryErr(tt.method, tt.attempt, tt.err); got != tt.want {
+			t.Errorf("%s: expected %t, got %t", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{2: time.Second, 3: 2 * time.Second, 4: 4 * time.Second, 5: 5 * time.Second, 10: 5 * time.Second} {
+		if got := p.Delay(attempt); got != want {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, want, got)
+		}
+	}
+
+	p.Jitter = 0.5
+	for range 100 {
+		if got := p.Delay(3); got < time.Second || got > 3*time.Second {
+			t.Fatalf("expected jittered delay in [1s, 3s], got %s", got)
+		}
+	}
+}
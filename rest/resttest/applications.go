@@ -0,0 +1,419 @@
+package resttest
+
+import (
+	"crypto/rand"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type interaction struct {
+	id           snowflake.ID
+	token        string
+	channelID    snowflake.ID
+	responseType discord.InteractionResponseType
+	original     object
+	followups    []object
+}
+
+func (in *interaction) message(messageID snowflake.ID) (object, error) {
+	if in.original != nil && in.original.id("id") == messageID {
+		return in.original, nil
+	}
+	for _, msg := range in.followups {
+		if msg.id("id") == messageID {
+			return msg, nil
+		}
+	}
+	return nil, errUnknown(rest.JSONErrorCodeUnknownMessage, "Message")
+}
+
+// GlobalCommands returns the global application commands.
+func (s *Server) GlobalCommands() []discord.ApplicationCommand {
+	return s.GuildCommands(0)
+}
+
+// GuildCommands returns the application commands of the given guild.
+func (s *Server) GuildCommands(guildID snowflake.ID) []discord.ApplicationCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var commands []discord.ApplicationCommand
+	for _, command := range s.scopeCommands(guildID) {
+		commands = append(commands, decode[discord.UnmarshalApplicationCommand](command).ApplicationCommand)
+	}
+	return commands
+}
+
+// CreateInteraction registers an interaction which can be responded to and returns its ID & token.
+// Messages sent in response to it are added to the given channel if it exists.
+func (s *Server) CreateInteraction(channelID snowflake.ID) (snowflake.ID, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	in := &interaction{
+		id:        s.newID(),
+		token:     rand.Text(),
+		channelID: channelID,
+	}
+	s.interactions[in.token] = in
+	return in.id, in.token
+}
+
+// InteractionResponseType returns the type of the response to the interaction with the given token or 0 if it was not responded to yet.
+func (s *Server) InteractionResponseType(interactionToken string) discord.InteractionResponseType {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if in, ok := s.interactions[interactionToken]; ok {
+		return in.responseType
+	}
+	return 0
+}
+
+// InteractionMessages returns the original response message followed by the followup messages of the interaction with the given token.
+func (s *Server) InteractionMessages(interactionToken string) []discord.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	in, ok := s.interactions[interactionToken]
+	if !ok {
+		return nil
+	}
+	var messages []object
+	if in.original != nil {
+		messages = append(messages, in.original)
+	}
+	return decode[[]discord.Message](append(messages, in.followups...))
+}
+
+// scopeCommands returns the commands of the guild or the global commands if guildID is 0, sorted by ID.
+func (s *Server) scopeCommands(guildID snowflake.ID) []object {
+	var commands []object
+	for _, command := range s.commands {
+		if command.id("guild_id") == guildID {
+			commands = append(commands, command)
+		}
+	}
+	slices.SortFunc(commands, func(a, b object) int {
+		return compareIDs(a.id("id"), b.id("id"))
+	})
+	return commands
+}
+
+// commandScope returns the guild ID of the command routes or 0 for global commands.
+func (s *Server) commandScope(r *http.Request) (snowflake.ID, error) {
+	if pathID(r, "application_id") != s.ApplicationID() {
+		return 0, errUnknown(rest.JSONErrorCodeUnknownApplication, "Application")
+	}
+	if r.PathValue("guild_id") == "" {
+		return 0, nil
+	}
+	g, err := s.guild(r)
+	if err != nil {
+		return 0, err
+	}
+	return g.id("id"), nil
+}
+
+func (s *Server) command(r *http.Request) (object, error) {
+	guildID, err := s.commandScope(r)
+	if err != nil {
+		return nil, err
+	}
+	command, ok := s.commands[pathID(r, "command_id")]
+	if !ok || command.id("guild_id") != guildID {
+		return nil, errUnknown(rest.JSONErrorCodeUnknownApplicationCommand, "application command")
+	}
+	return command, nil
+}
+
+func commandType(command object) int {
+	if t := command.int("type"); t != 0 {
+		return t
+	}
+	return int(discord.ApplicationCommandTypeSlash)
+}
+
+// upsertCommand creates a new command or overwrites the command of the scope with the same name & type like Discord does.
+func (s *Server) upsertCommand(guildID snowflake.ID, create object) (object, error) {
+	if create.string("name") == "" {
+		return nil, errFieldRequired("name")
+	}
+
+	for _, command := range s.scopeCommands(guildID) {
+		if command.string("name") == create.string("name") && commandType(command) == commandType(create) {
+			command.merge(create, "id", "application_id", "guild_id", "version")
+			command["version"] = s.newID().String()
+			return command, nil
+		}
+	}
+
+	id := s.newID()
+	command := object{
+		"type":                       int(discord.ApplicationCommandTypeSlash),
+		"description":                "",
+		"default_member_permissions": nil,
+		"nsfw":                       false,
+	}
+	command.merge(create, "id", "application_id", "guild_id", "version")
+	command["id"] = id.String()
+	command["application_id"] = s.ApplicationID().String()
+	command["version"] = id.String()
+	if guildID != 0 {
+		command["guild_id"] = guildID.String()
+	}
+	s.commands[id] = command
+	return command, nil
+}
+
+func (s *Server) commandRoutes() {
+	for _, prefix := range []string{"/applications/{application_id}", "/applications/{application_id}/guilds/{guild_id}"} {
+		s.handle("GET "+prefix+"/commands", true, func(r *http.Request) (any, error) {
+			guildID, err := s.commandScope(r)
+			if err != nil {
+				return nil, err
+			}
+			commands := s.scopeCommands(guildID)
+			if commands == nil {
+				commands = []object{}
+			}
+			return commands, nil
+		})
+
+		s.handle("POST "+prefix+"/commands", true, func(r *http.Request) (any, error) {
+			guildID, err := s.commandScope(r)
+			if err != nil {
+				return nil, err
+			}
+			var create object
+			if _, err = s.readBody(r, &create); err != nil {
+				return nil, err
+			}
+			return s.upsertCommand(guildID, create)
+		})
+
+		s.handle("PUT "+prefix+"/commands", true, func(r *http.Request) (any, error) {
+			guildID, err := s.commandScope(r)
+			if err != nil {
+				return nil, err
+			}
+			var creates []object
+			if _, err = s.readBody(r, &creates); err != nil {
+				return nil, err
+			}
+			for i, create := range creates {
+				if create.string("name") == "" {
+					return nil, errFieldRequired(strconv.Itoa(i) + ".name")
+				}
+			}
+
+			commands := make([]object, 0, len(creates))
+			keep := make([]snowflake.ID, 0, len(creates))
+			for _, create := range creates {
+				command, _ := s.upsertCommand(guildID, create)
+				commands = append(commands, command)
+				keep = append(keep, command.id("id"))
+			}
+			for _, command := range s.scopeCommands(guildID) {
+				if !slices.Contains(keep, command.id("id")) {
+					delete(s.commands, command.id("id"))
+				}
+			}
+			return commands, nil
+		})
+
+		getCommand := func(r *http.Request) (any, error) {
+			return s.command(r)
+		}
+		s.handle("GET "+prefix+"/commands/{command_id}", true, getCommand)
+		// the rest package requests single commands with the singular form of the route
+		s.handle("GET "+prefix+"/command/{command_id}", true, getCommand)
+
+		s.handle("PATCH "+prefix+"/commands/{command_id}", true, func(r *http.Request) (any, error) {
+			command, err := s.command(r)
+			if err != nil {
+				return nil, err
+			}
+			var update object
+			if _, err = s.readBody(r, &update); err != nil {
+				return nil, err
+			}
+			command.merge(update, "id", "application_id", "guild_id", "version", "type")
+			command["version"] = s.newID().String()
+			return command, nil
+		})
+
+		s.handle("DELETE "+prefix+"/commands/{command_id}", true, func(r *http.Request) (any, error) {
+			command, err := s.command(r)
+			if err != nil {
+				return nil, err
+			}
+			delete(s.commands, command.id("id"))
+			return nil, nil
+		})
+	}
+}
+
+// tokenInteraction returns the interaction if the webhook routes are used with the application ID & an interaction token.
+func (s *Server) tokenInteraction(r *http.Request) *interaction {
+	if pathID(r, "webhook_id") != s.ApplicationID() {
+		return nil
+	}
+	return s.interactions[r.PathValue("webhook_token")]
+}
+
+func (s *Server) interactionMessage(in *interaction, payload object, files []object) object {
+	msg := s.newMessage(in.channelID, s.users[s.config.BotUser.ID], payload, files)
+	msg["webhook_id"] = s.ApplicationID().String()
+	msg["application_id"] = s.ApplicationID().String()
+	return msg
+}
+
+func (s *Server) deleteInteractionMessage(in *interaction, msg object) {
+	s.deleteMessage(msg)
+	if in.original != nil && in.original.id("id") == msg.id("id") {
+		in.original = nil
+		return
+	}
+	in.followups = slices.DeleteFunc(in.followups, func(other object) bool {
+		return other.id("id") == msg.id("id")
+	})
+}
+
+func (s *Server) createFollowup(r *http.Request, in *interaction) (any, error) {
+	if in.responseType == 0 {
+		return nil, errUnknown(rest.JSONErrorCodeUnknownWebhook, "Webhook")
+	}
+	var create object
+	files, err := s.readBody(r, &create)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateMessage(create, files); err != nil {
+		return nil, err
+	}
+
+	// the first followup of a deferred response replaces the loading message
+	if in.original != nil && discord.MessageFlags(in.original.int("flags")).Has(discord.MessageFlagLoading) {
+		in.original["flags"] = in.original.int("flags") &^ int(discord.MessageFlagLoading)
+		updateMessage(in.original, create, files)
+		return in.original, nil
+	}
+
+	msg := s.interactionMessage(in, create, files)
+	in.followups = append(in.followups, msg)
+	return msg, nil
+}
+
+func (s *Server) originalMessage(r *http.Request) (*interaction, object, error) {
+	in := s.tokenInteraction(r)
+	if in == nil {
+		return nil, nil, errUnknown(rest.JSONErrorCodeUnknownWebhook, "Webhook")
+	}
+	if in.original == nil {
+		return nil, nil, errUnknown(rest.JSONErrorCodeUnknownMessage, "Message")
+	}
+	return in, in.original, nil
+}
+
+func (s *Server) interactionRoutes() {
+	s.handle("POST /interactions/{interaction_id}/{interaction_token}/callback", false, func(r *http.Request) (any, error) {
+		in, ok := s.interactions[r.PathValue("interaction_token")]
+		if !ok || in.id != pathID(r, "interaction_id") {
+			return nil, errUnknown(rest.JSONErrorCodeUnknownInteraction, "interaction")
+		}
+		if in.responseType != 0 {
+			return nil, errBadRequest(rest.JSONErrorCodeInteractionAlreadyAcknowledged, "Interaction has already been acknowledged.")
+		}
+
+		var response struct {
+			Type discord.InteractionResponseType `json:"type"`
+			Data object                          `json:"data"`
+		}
+		files, err := s.readBody(r, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.Type {
+		case discord.InteractionResponseTypeCreateMessage:
+			if err = validateMessage(response.Data, files); err != nil {
+				return nil, err
+			}
+			in.original = s.interactionMessage(in, response.Data, files)
+
+		case discord.InteractionResponseTypeDeferredCreateMessage:
+			flags := discord.MessageFlags(response.Data.int("flags")).Add(discord.MessageFlagLoading)
+			in.original = s.interactionMessage(in, object{"flags": int(flags)}, nil)
+
+		case discord.InteractionResponseTypePong,
+			discord.InteractionResponseTypeDeferredUpdateMessage,
+			discord.InteractionResponseTypeUpdateMessage,
+			discord.InteractionResponseTypeAutocompleteResult,
+			discord.InteractionResponseTypeModal,
+			discord.InteractionResponseTypeLaunchActivity:
+
+		default:
+			return nil, errInvalidField("type", "BASE_TYPE_CHOICES", "Value must be one of the valid interaction callback types.")
+		}
+		in.responseType = response.Type
+
+		if r.URL.Query().Get("with_response") != "true" {
+			return nil, nil
+		}
+		callback := object{
+			"id":                         in.id.String(),
+			"type":                       discord.InteractionTypeApplicationCommand,
+			"response_message_id":        nil,
+			"response_message_loading":   false,
+			"response_message_ephemeral": false,
+		}
+		resource := object{"type": response.Type}
+		if in.original != nil {
+			flags := discord.MessageFlags(in.original.int("flags"))
+			callback["response_message_id"] = in.original["id"]
+			callback["response_message_loading"] = flags.Has(discord.MessageFlagLoading)
+			callback["response_message_ephemeral"] = flags.Has(discord.MessageFlagEphemeral)
+			resource["message"] = in.original
+		}
+		return object{"interaction": callback, "resource": resource}, nil
+	})
+
+	s.handle("GET /webhooks/{webhook_id}/{webhook_token}/messages/@original", false, func(r *http.Request) (any, error) {
+		_, msg, err := s.originalMessage(r)
+		if err != nil {
+			return nil, err
+		}
+		return msg, nil
+	})
+
+	s.handle("PATCH /webhooks/{webhook_id}/{webhook_token}/messages/@original", false, func(r *http.Request) (any, error) {
+		_, msg, err := s.originalMessage(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		files, err := s.readBody(r, &update)
+		if err != nil {
+			return nil, err
+		}
+		msg["flags"] = msg.int("flags") &^ int(discord.MessageFlagLoading)
+		updateMessage(msg, update, files)
+		return msg, nil
+	})
+
+	s.handle("DELETE /webhooks/{webhook_id}/{webhook_token}/messages/@original", false, func(r *http.Request) (any, error) {
+		in, msg, err := s.originalMessage(r)
+		if err != nil {
+			return nil, err
+		}
+		s.deleteInteractionMessage(in, msg)
+		return nil, nil
+	})
+}
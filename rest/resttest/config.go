@@ -0,0 +1,73 @@
+package resttest
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func defaultConfig() config {
+	return config{
+		Logger: slog.Default(),
+		BotUser: discord.User{
+			ID:       snowflake.New(time.Now()),
+			Username: "resttest",
+			Bot:      true,
+		},
+		RateLimit:           50,
+		RateLimitResetAfter: time.Second,
+	}
+}
+
+type config struct {
+	Logger              *slog.Logger
+	Token               string
+	BotUser             discord.User
+	RateLimit           int
+	RateLimitResetAfter time.Duration
+}
+
+// ConfigOpt can be used to supply optional parameters to NewServer
+type ConfigOpt func(config *config)
+
+func (c *config) apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "resttest"))
+}
+
+// WithLogger applies a custom logger to the Server
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *config) {
+		config.Logger = logger
+	}
+}
+
+// WithToken makes the Server reject bot authenticated requests which don't use the given bot token.
+// By default, any bot token is accepted.
+func WithToken(token string) ConfigOpt {
+	return func(config *config) {
+		config.Token = token
+	}
+}
+
+// WithBotUser sets the user returned by /users/@me and used as author of messages sent by the bot.
+// Its ID is also used as the application ID.
+func WithBotUser(user discord.User) ConfigOpt {
+	return func(config *config) {
+		config.BotUser = user
+	}
+}
+
+// WithRateLimit sets how many requests each route & major parameter combination allows per resetAfter.
+// Requests above the limit receive a 429 response.
+func WithRateLimit(limit int, resetAfter time.Duration) ConfigOpt {
+	return func(config *config) {
+		config.RateLimit = limit
+		config.RateLimitResetAfter = resetAfter
+	}
+}
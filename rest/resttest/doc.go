@@ -0,0 +1,10 @@
+// Package resttest provides an in-memory fake of the Discord REST API for tests.
+//
+// The Server keeps state for guilds, channels, messages, members, roles, webhooks, application commands, interaction callbacks & lobbies,
+// responds with realistic rate limit headers and uses the JSON error codes of the rest package:
+//
+//	fake := resttest.NewServer()
+//	defer fake.Close()
+//
+//	client := rest.New(rest.NewClient("token", rest.WithURL(fake.URL)))
+package resttest
@@ -0,0 +1,648 @@
+package resttest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type guild struct {
+	object
+	roles   map[snowflake.ID]object
+	members map[snowflake.ID]object
+}
+
+// sortedRoles returns the roles of the guild sorted by position.
+func (g *guild) sortedRoles() []object {
+	roles := make([]object, 0, len(g.roles))
+	for _, role := range g.roles {
+		roles = append(roles, role)
+	}
+	slices.SortFunc(roles, func(a, b object) int {
+		if a.int("position") != b.int("position") {
+			return a.int("position") - b.int("position")
+		}
+		return compareIDs(a.id("id"), b.id("id"))
+	})
+	return roles
+}
+
+func (g *guild) sortedMembers() []object {
+	members := make([]object, 0, len(g.members))
+	for _, member := range g.members {
+		members = append(members, member)
+	}
+	slices.SortFunc(members, func(a, b object) int {
+		return compareIDs(memberUserID(a), memberUserID(b))
+	})
+	return members
+}
+
+func memberUserID(member object) snowflake.ID {
+	return asObject(member["user"]).id("id")
+}
+
+func compareIDs(a snowflake.ID, b snowflake.ID) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AddUser adds a user which can be fetched and added to guilds with AddMember.
+func (s *Server) AddUser(user discord.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = toObject(user)
+}
+
+// CreateGuild creates a guild with an @everyone role which the bot user is a member of.
+func (s *Server) CreateGuild(name string) snowflake.ID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	g := &guild{
+		object: object{
+			"id":                            id.String(),
+			"name":                          name,
+			"icon":                          nil,
+			"owner_id":                      s.config.BotUser.ID.String(),
+			"afk_timeout":                   300,
+			"verification_level":            0,
+			"default_message_notifications": 0,
+			"explicit_content_filter":       0,
+			"features":                      []any{},
+			"mfa_level":                     0,
+			"system_channel_flags":          0,
+			"premium_tier":                  0,
+			"preferred_locale":              "en-US",
+			"nsfw_level":                    0,
+		},
+		roles:   map[snowflake.ID]object{},
+		members: map[snowflake.ID]object{},
+	}
+	g.roles[id] = newRole(id, "@everyone", 0)
+	g.members[s.config.BotUser.ID] = newMember(s.users[s.config.BotUser.ID], nil)
+	s.guilds[id] = g
+	return id
+}
+
+// CreateChannel creates a channel in the given guild. If guildID is 0, the channel is not part of a guild.
+func (s *Server) CreateChannel(guildID snowflake.ID, channelType discord.ChannelType, name string) snowflake.ID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	c := object{
+		"id":   id.String(),
+		"type": channelType,
+		"name": name,
+	}
+	if guildID != 0 {
+		c["guild_id"] = guildID.String()
+		c["position"] = 0
+		c["permission_overwrites"] = []any{}
+	}
+	s.channels[id] = &channel{object: c}
+	return id
+}
+
+// AddMember adds the user as member with the given roles to the guild.
+func (s *Server) AddMember(guildID snowflake.ID, user discord.User, roleIDs ...snowflake.ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.guilds[guildID]
+	if !ok {
+		return
+	}
+	s.users[user.ID] = toObject(user)
+	g.members[user.ID] = newMember(s.users[user.ID], roleIDs)
+}
+
+// Channel returns the channel with the given ID.
+func (s *Server) Channel(channelID snowflake.ID) (discord.Channel, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.channels[channelID]
+	if !ok {
+		return nil, false
+	}
+	return decode[discord.UnmarshalChannel](c.object).Channel, true
+}
+
+// Member returns the member of the given guild.
+func (s *Server) Member(guildID snowflake.ID, userID snowflake.ID) (discord.Member, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.guilds[guildID]
+	if !ok {
+		return discord.Member{}, false
+	}
+	member, ok := g.members[userID]
+	if !ok {
+		return discord.Member{}, false
+	}
+	m := decode[discord.Member](member)
+	m.GuildID = guildID
+	return m, true
+}
+
+// Roles returns the roles of the given guild sorted by position.
+func (s *Server) Roles(guildID snowflake.ID) []discord.Role {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.guilds[guildID]
+	if !ok {
+		return nil
+	}
+	roles := decode[[]discord.Role](g.sortedRoles())
+	for i := range roles {
+		roles[i].GuildID = guildID
+	}
+	return roles
+}
+
+func newRole(id snowflake.ID, name string, position int) object {
+	return object{
+		"id":            id.String(),
+		"name":          name,
+		"color":         0,
+		"colors":        object{"primary_color": 0},
+		"hoist":         false,
+		"icon":          nil,
+		"unicode_emoji": nil,
+		"position":      position,
+		"permissions":   "0",
+		"managed":       false,
+		"mentionable":   false,
+		"flags":         0,
+	}
+}
+
+func newMember(user object, roleIDs []snowflake.ID) object {
+	roles := make([]any, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		roles = append(roles, roleID.String())
+	}
+	return object{
+		"user":                         user,
+		"nick":                         nil,
+		"avatar":                       nil,
+		"roles":                        roles,
+		"joined_at":                    timestamp(),
+		"deaf":                         false,
+		"mute":                         false,
+		"flags":                        0,
+		"pending":                      false,
+		"communication_disabled_until": nil,
+	}
+}
+
+func (s *Server) guild(r *http.Request) (*guild, error) {
+	g, ok := s.guilds[pathID(r, "guild_id")]
+	if !ok {
+		return nil, errUnknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	return g, nil
+}
+
+func (s *Server) userRoutes() {
+	s.handle("GET /users/@me", true, func(r *http.Request) (any, error) {
+		return s.users[s.config.BotUser.ID], nil
+	})
+
+	s.handle("GET /users/{user_id}", true, func(r *http.Request) (any, error) {
+		user, ok := s.users[pathID(r, "user_id")]
+		if !ok {
+			return nil, errUnknown(rest.JSONErrorCodeUnknownUser, "User")
+		}
+		return user, nil
+	})
+
+	s.handle("GET /users/@me/guilds", true, func(r *http.Request) (any, error) {
+		guilds := make([]object, 0, len(s.guilds))
+		for _, g := range s.guilds {
+			guilds = append(guilds, object{
+				"id":          g.object["id"],
+				"name":        g.object["name"],
+				"icon":        g.object["icon"],
+				"owner":       g.id("owner_id") == s.config.BotUser.ID,
+				"permissions": "0",
+				"features":    g.object["features"],
+			})
+		}
+		slices.SortFunc(guilds, func(a, b object) int {
+			return compareIDs(a.id("id"), b.id("id"))
+		})
+		return guilds, nil
+	})
+
+	s.handle("DELETE /users/@me/guilds/{guild_id}", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.guilds, g.id("id"))
+		return nil, nil
+	})
+}
+
+func (s *Server) guildRoutes() {
+	s.handle("GET /guilds/{guild_id}", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		rs := g.copy()
+		rs["roles"] = g.sortedRoles()
+		rs["emojis"] = []any{}
+		rs["stickers"] = []any{}
+		if r.URL.Query().Get("with_counts") == "true" {
+			rs["approximate_member_count"] = len(g.members)
+		}
+		return rs, nil
+	})
+
+	s.handle("PATCH /guilds/{guild_id}", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		if _, err = s.readBody(r, &update); err != nil {
+			return nil, err
+		}
+		g.merge(update, "id")
+		rs := g.copy()
+		rs["roles"] = g.sortedRoles()
+		return rs, nil
+	})
+}
+
+func (s *Server) channelRoutes() {
+	s.handle("GET /guilds/{guild_id}/channels", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		return s.guildChannels(g.id("id")), nil
+	})
+
+	s.handle("POST /guilds/{guild_id}/channels", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		var create object
+		if _, err = s.readBody(r, &create); err != nil {
+			return nil, err
+		}
+		if create.string("name") == "" {
+			return nil, errFieldRequired("name")
+		}
+
+		id := s.newID()
+		c := object{
+			"type":                  discord.ChannelTypeGuildText,
+			"position":              len(s.guildChannels(g.id("id"))),
+			"permission_overwrites": []any{},
+		}
+		c.merge(create)
+		c["id"] = id.String()
+		c["guild_id"] = g.object["id"]
+		s.channels[id] = &channel{object: c}
+		return c, nil
+	})
+
+	s.handle("PATCH /guilds/{guild_id}/channels", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		var positions []object
+		if _, err = s.readBody(r, &positions); err != nil {
+			return nil, err
+		}
+		for _, position := range positions {
+			c, ok := s.channels[position.id("id")]
+			if !ok || c.id("guild_id") != g.id("id") {
+				return nil, errUnknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+			}
+			c.merge(position, "id", "lock_permissions")
+		}
+		return nil, nil
+	})
+
+	s.handle("GET /channels/{channel_id}", true, func(r *http.Request) (any, error) {
+		c, err := s.channel(r)
+		if err != nil {
+			return nil, err
+		}
+		return c.object, nil
+	})
+
+	s.handle("PATCH /channels/{channel_id}", true, func(r *http.Request) (any, error) {
+		c, err := s.channel(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		if _, err = s.readBody(r, &update); err != nil {
+			return nil, err
+		}
+		c.merge(update, "id", "guild_id")
+		return c.object, nil
+	})
+
+	s.handle("DELETE /channels/{channel_id}", true, func(r *http.Request) (any, error) {
+		c, err := s.channel(r)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.channels, c.id("id"))
+		for id, webhook := range s.webhooks {
+			if webhook.id("channel_id") == c.id("id") {
+				delete(s.webhooks, id)
+			}
+		}
+		return c.object, nil
+	})
+}
+
+func (s *Server) guildChannels(guildID snowflake.ID) []object {
+	var channels []object
+	for _, c := range s.channels {
+		if c.id("guild_id") == guildID {
+			channels = append(channels, c.object)
+		}
+	}
+	slices.SortFunc(channels, func(a, b object) int {
+		if a.int("position") != b.int("position") {
+			return a.int("position") - b.int("position")
+		}
+		return compareIDs(a.id("id"), b.id("id"))
+	})
+	return channels
+}
+
+func (s *Server) roleRoutes() {
+	s.handle("GET /guilds/{guild_id}/roles", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		return g.sortedRoles(), nil
+	})
+
+	s.handle("GET /guilds/{guild_id}/roles/{role_id}", true, func(r *http.Request) (any, error) {
+		_, role, err := s.role(r)
+		if err != nil {
+			return nil, err
+		}
+		return role, nil
+	})
+
+	s.handle("POST /guilds/{guild_id}/roles", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		var create object
+		if _, err = s.readBody(r, &create); err != nil {
+			return nil, err
+		}
+
+		id := s.newID()
+		role := newRole(id, "new role", 1)
+		role.merge(create, "id", "managed", "position")
+		// new roles are created right above @everyone
+		for _, other := range g.roles {
+			if other.int("position") >= 1 {
+				other["position"] = other.int("position") + 1
+			}
+		}
+		g.roles[id] = role
+		return role, nil
+	})
+
+	s.handle("PATCH /guilds/{guild_id}/roles", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		var positions []object
+		if _, err = s.readBody(r, &positions); err != nil {
+			return nil, err
+		}
+		for _, position := range positions {
+			role, ok := g.roles[position.id("id")]
+			if !ok {
+				return nil, errUnknown(rest.JSONErrorCodeUnknownRole, "Role")
+			}
+			role["position"] = position["position"]
+		}
+		return g.sortedRoles(), nil
+	})
+
+	s.handle("PATCH /guilds/{guild_id}/roles/{role_id}", true, func(r *http.Request) (any, error) {
+		_, role, err := s.role(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		if _, err = s.readBody(r, &update); err != nil {
+			return nil, err
+		}
+		role.merge(update, "id", "managed", "position")
+		return role, nil
+	})
+
+	s.handle("DELETE /guilds/{guild_id}/roles/{role_id}", true, func(r *http.Request) (any, error) {
+		g, role, err := s.role(r)
+		if err != nil {
+			return nil, err
+		}
+		roleID := role.id("id")
+		if roleID == g.id("id") {
+			return nil, errBadRequest(rest.JSONErrorCodeInvalidRole, "Invalid Role")
+		}
+		delete(g.roles, roleID)
+		for _, member := range g.members {
+			removeID(member, "roles", roleID)
+		}
+		return nil, nil
+	})
+}
+
+func (s *Server) role(r *http.Request) (*guild, object, error) {
+	g, err := s.guild(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	role, ok := g.roles[pathID(r, "role_id")]
+	if !ok {
+		return nil, nil, errUnknown(rest.JSONErrorCodeUnknownRole, "Role")
+	}
+	return g, role, nil
+}
+
+func (s *Server) memberRoutes() {
+	s.handle("GET /guilds/{guild_id}/members", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		limit := queryLimit(r, 1, 1000)
+		after := queryID(r, "after")
+
+		members := make([]object, 0, limit)
+		for _, member := range g.sortedMembers() {
+			if len(members) == limit {
+				break
+			}
+			if memberUserID(member) > after {
+				members = append(members, member)
+			}
+		}
+		return members, nil
+	})
+
+	s.handle("GET /guilds/{guild_id}/members/search", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		query := strings.ToLower(r.URL.Query().Get("query"))
+		if query == "" {
+			return nil, errFieldRequired("query")
+		}
+		limit := queryLimit(r, 1, 1000)
+
+		members := make([]object, 0, limit)
+		for _, member := range g.sortedMembers() {
+			if len(members) == limit {
+				break
+			}
+			username := asObject(member["user"]).string("username")
+			if strings.HasPrefix(strings.ToLower(username), query) || strings.HasPrefix(strings.ToLower(member.string("nick")), query) {
+				members = append(members, member)
+			}
+		}
+		return members, nil
+	})
+
+	s.handle("GET /guilds/{guild_id}/members/{user_id}", true, func(r *http.Request) (any, error) {
+		_, member, err := s.member(r)
+		if err != nil {
+			return nil, err
+		}
+		return member, nil
+	})
+
+	s.handle("PATCH /guilds/{guild_id}/members/{user_id}", true, func(r *http.Request) (any, error) {
+		g, member, err := s.member(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		if _, err = s.readBody(r, &update); err != nil {
+			return nil, err
+		}
+		for _, roleID := range update.ids("roles") {
+			if _, ok := g.roles[roleID]; !ok {
+				return nil, errUnknown(rest.JSONErrorCodeUnknownRole, "Role")
+			}
+		}
+		member.merge(update, "user", "joined_at", "channel_id")
+		return member, nil
+	})
+
+	s.handle("PATCH /guilds/{guild_id}/members/@me", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		if _, err = s.readBody(r, &update); err != nil {
+			return nil, err
+		}
+		member := g.members[s.config.BotUser.ID]
+		if member == nil {
+			return nil, errUnknown(rest.JSONErrorCodeUnknownMember, "Member")
+		}
+		member.merge(update, "user", "joined_at", "roles")
+		return member, nil
+	})
+
+	s.handle("DELETE /guilds/{guild_id}/members/{user_id}", true, func(r *http.Request) (any, error) {
+		g, member, err := s.member(r)
+		if err != nil {
+			return nil, err
+		}
+		delete(g.members, memberUserID(member))
+		return nil, nil
+	})
+
+	s.handle("PUT /guilds/{guild_id}/members/{user_id}/roles/{role_id}", true, func(r *http.Request) (any, error) {
+		g, member, err := s.member(r)
+		if err != nil {
+			return nil, err
+		}
+		roleID := pathID(r, "role_id")
+		if _, ok := g.roles[roleID]; !ok {
+			return nil, errUnknown(rest.JSONErrorCodeUnknownRole, "Role")
+		}
+		if !slices.Contains(member.ids("roles"), roleID) {
+			roles, _ := member["roles"].([]any)
+			member["roles"] = append(roles, roleID.String())
+		}
+		return nil, nil
+	})
+
+	s.handle("DELETE /guilds/{guild_id}/members/{user_id}/roles/{role_id}", true, func(r *http.Request) (any, error) {
+		g, member, err := s.member(r)
+		if err != nil {
+			return nil, err
+		}
+		roleID := pathID(r, "role_id")
+		if _, ok := g.roles[roleID]; !ok {
+			return nil, errUnknown(rest.JSONErrorCodeUnknownRole, "Role")
+		}
+		removeID(member, "roles", roleID)
+		return nil, nil
+	})
+}
+
+func (s *Server) member(r *http.Request) (*guild, object, error) {
+	g, err := s.guild(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	member, ok := g.members[pathID(r, "user_id")]
+	if !ok {
+		return nil, nil, errUnknown(rest.JSONErrorCodeUnknownMember, "Member")
+	}
+	return g, member, nil
+}
+
+// removeID removes the ID from the ID array field of the object.
+func removeID(o object, key string, id snowflake.ID) {
+	values, _ := o[key].([]any)
+	o[key] = slices.DeleteFunc(values, func(value any) bool {
+		return value == id.String()
+	})
+}
@@ -0,0 +1,160 @@
+package resttest
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type lobby struct {
+	object
+	members map[snowflake.ID]object
+}
+
+// response returns the lobby with its members sorted by ID.
+func (l *lobby) response() object {
+	members := make([]object, 0, len(l.members))
+	for _, member := range l.members {
+		members = append(members, member)
+	}
+	slices.SortFunc(members, func(a, b object) int {
+		return compareIDs(a.id("id"), b.id("id"))
+	})
+	o := l.object.copy()
+	o["members"] = members
+	return o
+}
+
+// Lobby returns the lobby with the given ID.
+func (s *Server) Lobby(lobbyID snowflake.ID) (discord.Lobby, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.lobbies[lobbyID]
+	if !ok {
+		return discord.Lobby{}, false
+	}
+	return decode[discord.Lobby](l.response()), true
+}
+
+func (s *Server) lobby(r *http.Request) (*lobby, error) {
+	l, ok := s.lobbies[pathID(r, "lobby_id")]
+	if !ok {
+		return nil, errUnknown(rest.JSONErrorCodeUnknownLobby, "Lobby")
+	}
+	return l, nil
+}
+
+// newLobbyMember returns a lobby member of a known user from a member add payload.
+func (s *Server) newLobbyMember(userID snowflake.ID, add object) (object, error) {
+	if _, ok := s.users[userID]; !ok {
+		return nil, errUnknown(rest.JSONErrorCodeUnknownUser, "User")
+	}
+	return object{
+		"id":       userID.String(),
+		"metadata": add["metadata"],
+		"flags":    add.int("flags"),
+	}, nil
+}
+
+// setLobbyMembers replaces the members of the lobby with the members of the payload.
+func (s *Server) setLobbyMembers(l *lobby, payload object) error {
+	members := map[snowflake.ID]object{}
+	for _, add := range asObjects(payload["members"]) {
+		member, err := s.newLobbyMember(add.id("id"), add)
+		if err != nil {
+			return err
+		}
+		members[add.id("id")] = member
+	}
+	l.members = members
+	return nil
+}
+
+func (s *Server) lobbyRoutes() {
+	s.handle("POST /lobbies", true, func(r *http.Request) (any, error) {
+		var create object
+		if _, err := s.readBody(r, &create); err != nil {
+			return nil, err
+		}
+
+		id := s.newID()
+		l := &lobby{object: object{
+			"id":             id.String(),
+			"application_id": s.ApplicationID().String(),
+			"metadata":       create["metadata"],
+		}}
+		if err := s.setLobbyMembers(l, create); err != nil {
+			return nil, err
+		}
+		s.lobbies[id] = l
+		return l.response(), nil
+	})
+
+	s.handle("GET /lobbies/{lobby_id}", true, func(r *http.Request) (any, error) {
+		l, err := s.lobby(r)
+		if err != nil {
+			return nil, err
+		}
+		return l.response(), nil
+	})
+
+	s.handle("PATCH /lobbies/{lobby_id}", true, func(r *http.Request) (any, error) {
+		l, err := s.lobby(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		if _, err = s.readBody(r, &update); err != nil {
+			return nil, err
+		}
+		if metadata, ok := update["metadata"]; ok {
+			l.object["metadata"] = metadata
+		}
+		if _, ok := update["members"]; ok {
+			if err = s.setLobbyMembers(l, update); err != nil {
+				return nil, err
+			}
+		}
+		return l.response(), nil
+	})
+
+	s.handle("DELETE /lobbies/{lobby_id}", true, func(r *http.Request) (any, error) {
+		l, err := s.lobby(r)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.lobbies, l.id("id"))
+		return nil, nil
+	})
+
+	s.handle("PUT /lobbies/{lobby_id}/members/{user_id}", true, func(r *http.Request) (any, error) {
+		l, err := s.lobby(r)
+		if err != nil {
+			return nil, err
+		}
+		var add object
+		if _, err = s.readBody(r, &add); err != nil {
+			return nil, err
+		}
+		member, err := s.newLobbyMember(pathID(r, "user_id"), add)
+		if err != nil {
+			return nil, err
+		}
+		l.members[member.id("id")] = member
+		return member, nil
+	})
+
+	s.handle("DELETE /lobbies/{lobby_id}/members/{user_id}", true, func(r *http.Request) (any, error) {
+		l, err := s.lobby(r)
+		if err != nil {
+			return nil, err
+		}
+		delete(l.members, pathID(r, "user_id"))
+		return nil, nil
+	})
+}
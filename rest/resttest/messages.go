@@ -0,0 +1,288 @@
+package resttest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type channel struct {
+	object
+	// messages are sorted by ID, oldest first
+	messages []object
+}
+
+// messageFields are fields of message create & update payloads which are not copied to the message.
+var messageFields = []string{"id", "channel_id", "guild_id", "author", "timestamp", "webhook_id", "application_id", "nonce", "enforce_nonce", "allowed_mentions", "sticker_ids", "attachments", "username", "avatar_url", "thread_name", "applied_tags"}
+
+// Messages returns the messages of the given channel, oldest first.
+func (s *Server) Messages(channelID snowflake.ID) []discord.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.channels[channelID]
+	if !ok {
+		return nil
+	}
+	return decode[[]discord.Message](c.messages)
+}
+
+// CreateMessage adds a message sent by the bot user at the given time to the channel, e.g. to test messages which are too old for a bulk delete.
+func (s *Server) CreateMessage(channelID snowflake.ID, content string, sentAt time.Time) snowflake.ID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.channels[channelID]
+	if !ok {
+		return 0
+	}
+	id := snowflake.New(sentAt)
+	for slices.ContainsFunc(c.messages, func(msg object) bool { return msg.id("id") == id }) {
+		id++
+	}
+	msg := s.newMessage(channelID, s.users[s.config.BotUser.ID], object{"content": content}, nil)
+	msg["id"] = id.String()
+	msg["timestamp"] = sentAt.UTC().Format(time.RFC3339Nano)
+	slices.SortFunc(c.messages, func(a, b object) int {
+		return compareIDs(a.id("id"), b.id("id"))
+	})
+	return id
+}
+
+func validateMessage(payload object, files []object) error {
+	if payload.string("content") != "" || len(files) > 0 || payload["poll"] != nil {
+		return nil
+	}
+	for _, key := range []string{"embeds", "components", "sticker_ids"} {
+		if values, _ := payload[key].([]any); len(values) > 0 {
+			return nil
+		}
+	}
+	return errBadRequest(rest.JSONErrorCodeCannotSendEmptyMessage, "Cannot send an empty message")
+}
+
+// newMessage creates a message from the create payload and adds it to the channel if the channel exists.
+func (s *Server) newMessage(channelID snowflake.ID, author object, payload object, files []object) object {
+	if files == nil {
+		files = []object{}
+	}
+	msg := object{
+		"id":               s.newID().String(),
+		"channel_id":       channelID.String(),
+		"author":           author,
+		"type":             discord.MessageTypeDefault,
+		"content":          "",
+		"timestamp":        timestamp(),
+		"edited_timestamp": nil,
+		"tts":              false,
+		"mention_everyone": false,
+		"mentions":         []any{},
+		"mention_roles":    []any{},
+		"attachments":      files,
+		"embeds":           []any{},
+		"components":       []any{},
+		"pinned":           false,
+		"flags":            0,
+	}
+	msg.merge(payload, messageFields...)
+	if payload["message_reference"] != nil {
+		msg["type"] = discord.MessageTypeReply
+	}
+
+	if c, ok := s.channels[channelID]; ok {
+		if guildID := c.id("guild_id"); guildID != 0 {
+			msg["guild_id"] = guildID.String()
+		}
+		c.messages = append(c.messages, msg)
+	}
+	return msg
+}
+
+// updateMessage applies the update payload to the message.
+// Attachments which are not listed in the attachments field of the payload are removed.
+func updateMessage(msg object, payload object, files []object) {
+	attachments := asObjects(msg["attachments"])
+	if rawKeep, ok := payload["attachments"].([]any); ok {
+		var keep []snowflake.ID
+		for _, attachment := range asObjects(rawKeep) {
+			keep = append(keep, attachment.id("id"))
+		}
+		attachments = slices.DeleteFunc(attachments, func(attachment object) bool {
+			return !slices.Contains(keep, attachment.id("id"))
+		})
+	}
+	msg["attachments"] = append(attachments, files...)
+
+	msg.merge(payload, append(messageFields, "type")...)
+	msg["edited_timestamp"] = timestamp()
+}
+
+func asObjects(v any) []object {
+	switch values := v.(type) {
+	case []object:
+		return slices.Clone(values)
+	case []any:
+		objects := make([]object, 0, len(values))
+		for _, value := range values {
+			if o := asObject(value); o != nil {
+				objects = append(objects, o)
+			}
+		}
+		return objects
+	default:
+		return []object{}
+	}
+}
+
+// deleteMessage removes the message from its channel.
+func (s *Server) deleteMessage(msg object) {
+	c, ok := s.channels[msg.id("channel_id")]
+	if !ok {
+		return
+	}
+	c.messages = slices.DeleteFunc(c.messages, func(other object) bool {
+		return other.id("id") == msg.id("id")
+	})
+}
+
+func (s *Server) channel(r *http.Request) (*channel, error) {
+	c, ok := s.channels[pathID(r, "channel_id")]
+	if !ok {
+		return nil, errUnknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	return c, nil
+}
+
+func (s *Server) message(r *http.Request) (*channel, object, error) {
+	c, err := s.channel(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	messageID := pathID(r, "message_id")
+	for _, msg := range c.messages {
+		if msg.id("id") == messageID {
+			return c, msg, nil
+		}
+	}
+	return nil, nil, errUnknown(rest.JSONErrorCodeUnknownMessage, "Message")
+}
+
+func (s *Server) messageRoutes() {
+	s.handle("GET /channels/{channel_id}/messages", true, func(r *http.Request) (any, error) {
+		c, err := s.channel(r)
+		if err != nil {
+			return nil, err
+		}
+		limit := queryLimit(r, 50, 100)
+		around, before, after := queryID(r, "around"), queryID(r, "before"), queryID(r, "after")
+
+		// the first index of a message with an ID >= id
+		index := func(id snowflake.ID) int {
+			i, _ := slices.BinarySearchFunc(c.messages, id, func(msg object, id snowflake.ID) int {
+				return compareIDs(msg.id("id"), id)
+			})
+			return i
+		}
+
+		var start, end int
+		switch {
+		case around != 0:
+			start = max(0, index(around)-limit/2)
+			end = min(len(c.messages), start+limit)
+		case after != 0:
+			start = index(after + 1)
+			end = min(len(c.messages), start+limit)
+		default:
+			end = len(c.messages)
+			if before != 0 {
+				end = index(before)
+			}
+			start = max(0, end-limit)
+		}
+
+		// messages are returned newest first
+		messages := slices.Clone(c.messages[start:end])
+		slices.Reverse(messages)
+		return messages, nil
+	})
+
+	s.handle("GET /channels/{channel_id}/messages/{message_id}", true, func(r *http.Request) (any, error) {
+		_, msg, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		return msg, nil
+	})
+
+	s.handle("POST /channels/{channel_id}/messages", true, func(r *http.Request) (any, error) {
+		c, err := s.channel(r)
+		if err != nil {
+			return nil, err
+		}
+		var create object
+		files, err := s.readBody(r, &create)
+		if err != nil {
+			return nil, err
+		}
+		if err = validateMessage(create, files); err != nil {
+			return nil, err
+		}
+		return s.newMessage(c.id("id"), s.users[s.config.BotUser.ID], create, files), nil
+	})
+
+	s.handle("PATCH /channels/{channel_id}/messages/{message_id}", true, func(r *http.Request) (any, error) {
+		_, msg, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		files, err := s.readBody(r, &update)
+		if err != nil {
+			return nil, err
+		}
+		if asObject(msg["author"]).id("id") != s.config.BotUser.ID {
+			return nil, &apiError{status: http.StatusForbidden, Code: rest.JSONErrorCodeCannotEditMessageAuthoredByAnotherUser, Message: "Cannot edit a message authored by another user"}
+		}
+		updateMessage(msg, update, files)
+		return msg, nil
+	})
+
+	s.handle("DELETE /channels/{channel_id}/messages/{message_id}", true, func(r *http.Request) (any, error) {
+		_, msg, err := s.message(r)
+		if err != nil {
+			return nil, err
+		}
+		s.deleteMessage(msg)
+		return nil, nil
+	})
+
+	s.handle("POST /channels/{channel_id}/messages/bulk-delete", true, func(r *http.Request) (any, error) {
+		c, err := s.channel(r)
+		if err != nil {
+			return nil, err
+		}
+		var bulkDelete object
+		if _, err = s.readBody(r, &bulkDelete); err != nil {
+			return nil, err
+		}
+		messageIDs := bulkDelete.ids("messages")
+		if len(messageIDs) < 2 || len(messageIDs) > 100 {
+			return nil, errBadRequest(rest.JSONErrorCodeTooFewOrTooManyMessagesToDelete, "You must provide at least 2 and fewer than 100 messages to delete.")
+		}
+		minID := snowflake.New(time.Now().Add(-rest.BulkDeleteMaxAge))
+		for _, messageID := range messageIDs {
+			if messageID < minID {
+				return nil, errBadRequest(rest.JSONErrorCodeMessageTooOldToBulkDelete, "You can only bulk delete messages that are under 14 days old.")
+			}
+		}
+		c.messages = slices.DeleteFunc(c.messages, func(msg object) bool {
+			return slices.Contains(messageIDs, msg.id("id"))
+		})
+		return nil, nil
+	})
+}
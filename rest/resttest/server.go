@@ -0,0 +1,447 @@
+package resttest
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"math"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// majorParameters are the path wildcards which decide in which bucket a request belongs, see rest.MajorParameters.
+var majorParameters = []string{"guild_id", "channel_id", "webhook_id", "webhook_token", "interaction_token"}
+
+// NewServer starts a new Server. Close it when done.
+func NewServer(opts ...ConfigOpt) *Server {
+	cfg := defaultConfig()
+	cfg.apply(opts)
+
+	s := &Server{
+		config:       cfg,
+		mux:          http.NewServeMux(),
+		users:        map[snowflake.ID]object{},
+		guilds:       map[snowflake.ID]*guild{},
+		channels:     map[snowflake.ID]*channel{},
+		webhooks:     map[snowflake.ID]object{},
+		commands:     map[snowflake.ID]object{},
+		interactions: map[string]*interaction{},
+		lobbies:      map[snowflake.ID]*lobby{},
+		buckets:      map[string]*bucket{},
+	}
+	s.users[cfg.BotUser.ID] = toObject(cfg.BotUser)
+
+	s.routes()
+	s.Server = httptest.NewServer(s.mux)
+	return s
+}
+
+// Server is an in-memory fake of the Discord REST API.
+// Its URL can be used with rest.WithURL.
+type Server struct {
+	*httptest.Server
+
+	config config
+	mux    *http.ServeMux
+
+	mu           sync.Mutex
+	lastID       snowflake.ID
+	users        map[snowflake.ID]object
+	guilds       map[snowflake.ID]*guild
+	channels     map[snowflake.ID]*channel
+	webhooks     map[snowflake.ID]object
+	commands     map[snowflake.ID]object
+	interactions map[string]*interaction
+	lobbies      map[snowflake.ID]*lobby
+
+	buckets   map[string]*bucket
+	bucketsMu sync.Mutex
+}
+
+type bucket struct {
+	remaining int
+	reset     time.Time
+}
+
+// route handles a request while the state of the Server is locked.
+// A nil response is sent as 204 No Content.
+type route func(r *http.Request) (any, error)
+
+// BotUser returns the user the Server acts as.
+func (s *Server) BotUser() discord.User {
+	return s.config.BotUser
+}
+
+// ApplicationID returns the ID of the application the Server acts as. It is the same as the ID of the BotUser.
+func (s *Server) ApplicationID() snowflake.ID {
+	return s.config.BotUser.ID
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Via", "1.1 google")
+		writeError(w, &apiError{status: http.StatusNotFound, Code: rest.JSONErrorCodeGeneral, Message: "404: Not Found"})
+	})
+
+	s.userRoutes()
+	s.guildRoutes()
+	s.channelRoutes()
+	s.roleRoutes()
+	s.memberRoutes()
+	s.messageRoutes()
+	s.webhookRoutes()
+	s.commandRoutes()
+	s.interactionRoutes()
+	s.lobbyRoutes()
+}
+
+func (s *Server) handle(pattern string, botAuth bool, h route) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		// the rest client treats 429s without a via header as cloudflare bans
+		w.Header().Set("Via", "1.1 google")
+
+		if botAuth && !s.authorized(r) {
+			writeError(w, &apiError{status: http.StatusUnauthorized, Code: rest.JSONErrorCodeGeneral, Message: "401: Unauthorized"})
+			return
+		}
+		if !s.rateLimit(w, r) {
+			return
+		}
+
+		s.mu.Lock()
+		v, err := h(r)
+		var data []byte
+		if err == nil && v != nil {
+			// marshal while locked, so the response is not modified by concurrent requests
+			data, err = json.Marshal(v)
+		}
+		s.mu.Unlock()
+
+		if err != nil {
+			s.config.Logger.Debug("request failed", slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.Any("err", err))
+			writeError(w, err)
+			return
+		}
+		if data == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), discord.TokenTypeBot.Apply(""))
+	if !ok || token == "" {
+		return false
+	}
+	return s.config.Token == "" || token == s.config.Token
+}
+
+// rateLimit counts the request against its bucket and sets the rate limit headers.
+// It returns false and responds with 429 Too Many Requests if the bucket is exhausted.
+func (s *Server) rateLimit(w http.ResponseWriter, r *http.Request) bool {
+	key := r.Pattern
+	for _, name := range majorParameters {
+		if value := r.PathValue(name); value != "" {
+			key += ":" + value
+		}
+	}
+
+	now := time.Now()
+	s.bucketsMu.Lock()
+	b, ok := s.buckets[key]
+	if !ok || !now.Before(b.reset) {
+		b = &bucket{
+			remaining: s.config.RateLimit,
+			reset:     now.Add(s.config.RateLimitResetAfter),
+		}
+		s.buckets[key] = b
+	}
+	limited := b.remaining <= 0
+	if !limited {
+		b.remaining--
+	}
+	remaining, reset := b.remaining, b.reset
+	s.bucketsMu.Unlock()
+
+	resetAfter := reset.Sub(now).Seconds()
+	h := w.Header()
+	h.Set("X-RateLimit-Bucket", fmt.Sprintf("%x", md5.Sum([]byte(r.Pattern))))
+	h.Set("X-RateLimit-Limit", strconv.Itoa(s.config.RateLimit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatFloat(float64(reset.UnixMilli())/1000, 'f', 3, 64))
+	h.Set("X-RateLimit-Reset-After", strconv.FormatFloat(resetAfter, 'f', 3, 64))
+	if !limited {
+		return true
+	}
+
+	s.config.Logger.Debug("rate limit exceeded", slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.Float64("retry_after", resetAfter))
+	h.Set("X-RateLimit-Scope", "user")
+	h.Set("Retry-After", strconv.Itoa(int(math.Ceil(resetAfter))))
+	writeJSON(w, http.StatusTooManyRequests, rateLimitResponse{
+		Message:    "You are being rate limited.",
+		RetryAfter: resetAfter,
+	})
+	return false
+}
+
+type rateLimitResponse struct {
+	Message    string  `json:"message"`
+	RetryAfter float64 `json:"retry_after"`
+	Global     bool    `json:"global"`
+}
+
+// apiError is an error response in the format of the Discord API.
+type apiError struct {
+	status  int
+	Code    rest.JSONErrorCode `json:"code"`
+	Message string             `json:"message"`
+	Errors  any                `json:"errors,omitempty"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+func errUnknown(code rest.JSONErrorCode, resource string) *apiError {
+	return &apiError{status: http.StatusNotFound, Code: code, Message: "Unknown " + resource}
+}
+
+func errBadRequest(code rest.JSONErrorCode, message string) *apiError {
+	return &apiError{status: http.StatusBadRequest, Code: code, Message: message}
+}
+
+func errFieldRequired(field string) *apiError {
+	return errInvalidField(field, "BASE_TYPE_REQUIRED", "This field is required")
+}
+
+// errInvalidField returns an Invalid Form Body error for the given field of the request body.
+func errInvalidField(field string, code string, message string) *apiError {
+	return &apiError{
+		status:  http.StatusBadRequest,
+		Code:    rest.JSONErrorCodeInvalidFormBody,
+		Message: "Invalid Form Body",
+		Errors: map[string]any{
+			field: map[string]any{
+				"_errors": []map[string]string{{"code": code, "message": message}},
+			},
+		},
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*apiError)
+	if !ok {
+		apiErr = &apiError{status: http.StatusInternalServerError, Code: rest.JSONErrorCodeGeneral, Message: err.Error()}
+	}
+	writeJSON(w, apiErr.status, apiErr)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// readBody decodes the JSON or multipart/form-data body of the request into v.
+// Files of multipart bodies are returned as attachment objects.
+func (s *Server) readBody(r *http.Request, v any) ([]object, error) {
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+			return nil, errBadRequest(rest.JSONErrorCodeRequestBodyContainsInvalidJSON, "The request body contains invalid JSON.")
+		}
+		return nil, nil
+	}
+
+	var (
+		payload     object
+		attachments []object
+	)
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errBadRequest(rest.JSONErrorCodeGeneral, "400: Bad Request")
+		}
+
+		name := part.FormName()
+		if name == "payload_json" {
+			if err = json.NewDecoder(part).Decode(&payload); err != nil {
+				return nil, errBadRequest(rest.JSONErrorCodeRequestBodyContainsInvalidJSON, "The request body contains invalid JSON.")
+			}
+			continue
+		}
+
+		index, ok := strings.CutPrefix(name, "files[")
+		if !ok {
+			continue
+		}
+		size, err := io.Copy(io.Discard, part)
+		if err != nil {
+			return nil, errBadRequest(rest.JSONErrorCodeGeneral, "400: Bad Request")
+		}
+		id := s.newID()
+		attachments = append(attachments, object{
+			"id":           id.String(),
+			"filename":     part.FileName(),
+			"size":         size,
+			"content_type": part.Header.Get("Content-Type"),
+			"url":          s.URL + "/attachments/" + id.String() + "/" + part.FileName(),
+			"proxy_url":    s.URL + "/attachments/" + id.String() + "/" + part.FileName(),
+			"index":        strings.TrimSuffix(index, "]"),
+		})
+	}
+
+	// descriptions of the files are sent in the attachments field of the payload, referencing the file index as id
+	if rawAttachments, ok := payload["attachments"].([]any); ok {
+		for _, rawAttachment := range rawAttachments {
+			attachment, _ := rawAttachment.(map[string]any)
+			for _, file := range attachments {
+				if fmt.Sprint(attachment["id"]) == file["index"] {
+					file["description"] = attachment["description"]
+				}
+			}
+		}
+	}
+	for _, attachment := range attachments {
+		delete(attachment, "index")
+	}
+
+	if payload != nil {
+		data, _ := json.Marshal(payload)
+		if err := json.Unmarshal(data, v); err != nil {
+			return nil, errBadRequest(rest.JSONErrorCodeRequestBodyContainsInvalidJSON, "The request body contains invalid JSON.")
+		}
+	}
+	return attachments, nil
+}
+
+// newID returns a new unique snowflake. The state must be locked.
+func (s *Server) newID() snowflake.ID {
+	id := snowflake.New(time.Now())
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	return id
+}
+
+func pathID(r *http.Request, name string) snowflake.ID {
+	id, _ := snowflake.Parse(r.PathValue(name))
+	return id
+}
+
+func queryID(r *http.Request, name string) snowflake.ID {
+	id, _ := snowflake.Parse(r.URL.Query().Get(name))
+	return id
+}
+
+func queryLimit(r *http.Request, defaultLimit int, maxLimit int) int {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		return defaultLimit
+	}
+	return min(limit, maxLimit)
+}
+
+// object is a resource as it is sent over the wire. Resources are stored as objects, so fields unknown to the Server are kept.
+type object map[string]any
+
+func toObject(v any) object {
+	data, _ := json.Marshal(v)
+	var o object
+	_ = json.Unmarshal(data, &o)
+	return o
+}
+
+// decode converts an object into the given type.
+func decode[T any](v any) T {
+	var t T
+	data, _ := json.Marshal(v)
+	_ = json.Unmarshal(data, &t)
+	return t
+}
+
+// asObject returns the nested object of a stored or decoded resource.
+func asObject(v any) object {
+	switch o := v.(type) {
+	case object:
+		return o
+	case map[string]any:
+		return o
+	default:
+		return nil
+	}
+}
+
+func (o object) id(key string) snowflake.ID {
+	value, _ := o[key].(string)
+	id, _ := snowflake.Parse(value)
+	return id
+}
+
+func (o object) string(key string) string {
+	value, _ := o[key].(string)
+	return value
+}
+
+func (o object) int(key string) int {
+	switch value := o[key].(type) {
+	case float64:
+		return int(value)
+	case int:
+		return value
+	default:
+		return 0
+	}
+}
+
+func (o object) ids(key string) []snowflake.ID {
+	values, _ := o[key].([]any)
+	ids := make([]snowflake.ID, 0, len(values))
+	for _, value := range values {
+		str, _ := value.(string)
+		if id, err := snowflake.Parse(str); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// merge sets all fields of update except the given ones which can't be changed.
+func (o object) merge(update object, immutable ...string) {
+	for key, value := range update {
+		if !slices.Contains(immutable, key) {
+			o[key] = value
+		}
+	}
+}
+
+func (o object) copy() object {
+	return maps.Clone(o)
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
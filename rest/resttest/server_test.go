@@ -0,0 +1,145 @@
+package resttest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func newTestClient(t *testing.T, opts ...ConfigOpt) (*Server, rest.Rest) {
+	t.Helper()
+	fake := NewServer(opts...)
+	t.Cleanup(fake.Close)
+	return fake, rest.New(rest.NewClient("token", rest.WithURL(fake.URL)))
+}
+
+func assertErrorCode(t *testing.T, err error, code rest.JSONErrorCode) {
+	t.Helper()
+	var restErr *rest.Error
+	if !errors.As(err, &restErr) {
+		t.Fatalf("expected *rest.Error, got %v", err)
+	}
+	if restErr.Code != code {
+		t.Fatalf("expected error code %d, got %d", code, restErr.Code)
+	}
+}
+
+func TestServer_Messages(t *testing.T) {
+	fake, client := newTestClient(t)
+	guildID := fake.CreateGuild("test")
+	channelID := fake.CreateChannel(guildID, discord.ChannelTypeGuildText, "general")
+
+	for _, content := range []string{"one", "two", "three"} {
+		if _, err := client.CreateMessage(channelID, discord.MessageCreate{Content: content}); err != nil {
+			t.Fatalf("unexpected error creating message: %v", err)
+		}
+	}
+	msg, err := client.CreateMessage(channelID, discord.MessageCreate{
+		Files: []*discord.File{discord.NewFile("file.txt", "a file", strings.NewReader("data"))},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating message with file: %v", err)
+	}
+	if len(msg.Attachments) != 1 || msg.Attachments[0].Filename != "file.txt" || msg.Attachments[0].Size != 4 {
+		t.Errorf("unexpected attachments: %+v", msg.Attachments)
+	}
+
+	messages, err := client.GetMessages(channelID, 0, msg.ID, 0, 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting messages: %v", err)
+	}
+	if len(messages) != 2 || messages[0].Content != "three" || messages[1].Content != "two" {
+		t.Errorf("expected messages three & two, got %+v", messages)
+	}
+
+	if _, err = client.UpdateMessage(channelID, messages[0].ID, discord.MessageUpdate{}.WithContent("edited")); err != nil {
+		t.Fatalf("unexpected error updating message: %v", err)
+	}
+	if stored := fake.Messages(channelID); len(stored) != 4 || stored[2].Content != "edited" || stored[2].EditedTimestamp == nil {
+		t.Errorf("expected the third message to be edited, got %+v", stored)
+	}
+
+	_, err = client.CreateMessage(channelID, discord.MessageCreate{})
+	assertErrorCode(t, err, rest.JSONErrorCodeCannotSendEmptyMessage)
+
+	_, err = client.GetMessage(1, msg.ID)
+	assertErrorCode(t, err, rest.JSONErrorCodeUnknownChannel)
+}
+
+func TestServer_Commands(t *testing.T) {
+	fake, client := newTestClient(t)
+
+	commands, err := client.SetGlobalCommands(fake.ApplicationID(), []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{Name: "ping", Description: "ping"},
+		discord.SlashCommandCreate{Name: "pong", Description: "pong"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error setting commands: %v", err)
+	}
+
+	updated, err := client.SetGlobalCommands(fake.ApplicationID(), []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{Name: "ping", Description: "new ping"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error setting commands: %v", err)
+	}
+	if len(updated) != 1 || updated[0].ID() != commands[0].ID() {
+		t.Errorf("expected ping to keep its ID, got %+v", updated)
+	}
+	if stored := fake.GlobalCommands(); len(stored) != 1 {
+		t.Errorf("expected pong to be removed, got %+v", stored)
+	}
+}
+
+func TestServer_Interaction(t *testing.T) {
+	fake, client := newTestClient(t)
+	channelID := fake.CreateChannel(fake.CreateGuild("test"), discord.ChannelTypeGuildText, "general")
+	interactionID, token := fake.CreateInteraction(channelID)
+
+	if err := client.CreateInteractionResponse(interactionID, token, discord.InteractionResponse{
+		Type: discord.InteractionResponseTypeDeferredCreateMessage,
+	}); err != nil {
+		t.Fatalf("unexpected error responding: %v", err)
+	}
+
+	err := client.CreateInteractionResponse(interactionID, token, discord.InteractionResponse{
+		Type: discord.InteractionResponseTypeCreateMessage,
+		Data: discord.MessageCreate{Content: "test"},
+	})
+	assertErrorCode(t, err, rest.JSONErrorCodeInteractionAlreadyAcknowledged)
+
+	msg, err := client.CreateFollowupMessage(fake.ApplicationID(), token, discord.MessageCreate{Content: "done"})
+	if err != nil {
+		t.Fatalf("unexpected error creating followup: %v", err)
+	}
+	if msg.Flags.Has(discord.MessageFlagLoading) {
+		t.Errorf("expected the first followup to replace the loading message")
+	}
+
+	messages := fake.InteractionMessages(token)
+	if len(messages) != 1 || messages[0].Content != "done" {
+		t.Errorf("expected a single interaction message, got %+v", messages)
+	}
+	if stored := fake.Messages(channelID); len(stored) != 1 || stored[0].ID != msg.ID {
+		t.Errorf("expected the response in the channel, got %+v", stored)
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	fake, client := newTestClient(t, WithRateLimit(2, time.Second))
+	channelID := fake.CreateChannel(0, discord.ChannelTypeDM, "")
+
+	start := time.Now()
+	for range 3 {
+		if _, err := client.GetChannel(channelID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+		t.Errorf("expected the third request to wait for the bucket reset, took %s", elapsed)
+	}
+}
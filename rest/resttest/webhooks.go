@@ -0,0 +1,278 @@
+package resttest
+
+import (
+	"crypto/rand"
+	"net/http"
+	"slices"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func (s *Server) webhook(r *http.Request) (object, error) {
+	webhook, ok := s.webhooks[pathID(r, "webhook_id")]
+	if !ok {
+		return nil, errUnknown(rest.JSONErrorCodeUnknownWebhook, "Webhook")
+	}
+	return webhook, nil
+}
+
+func (s *Server) webhookWithToken(r *http.Request) (object, error) {
+	webhook, err := s.webhook(r)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.string("token") != r.PathValue("webhook_token") {
+		return nil, &apiError{status: http.StatusUnauthorized, Code: rest.JSONErrorCodeInvalidWebhookToken, Message: "Invalid Webhook Token"}
+	}
+	return webhook, nil
+}
+
+// withoutUser returns the webhook as it is returned by the routes which are authenticated with the webhook token.
+func withoutUser(webhook object) object {
+	webhook = webhook.copy()
+	delete(webhook, "user")
+	return webhook
+}
+
+// webhookChannel returns the channel the webhook sends messages to, which is the thread_id query parameter if set.
+func (s *Server) webhookChannel(r *http.Request, webhook object) snowflake.ID {
+	if threadID := queryID(r, "thread_id"); threadID != 0 {
+		return threadID
+	}
+	return webhook.id("channel_id")
+}
+
+func (s *Server) webhookMessage(r *http.Request, webhook object) (object, error) {
+	c, ok := s.channels[s.webhookChannel(r, webhook)]
+	if ok {
+		messageID := pathID(r, "message_id")
+		for _, msg := range c.messages {
+			if msg.id("id") == messageID && msg.id("webhook_id") == webhook.id("id") {
+				return msg, nil
+			}
+		}
+	}
+	return nil, errUnknown(rest.JSONErrorCodeUnknownMessage, "Message")
+}
+
+func (s *Server) webhookRoutes() {
+	s.handle("POST /channels/{channel_id}/webhooks", true, func(r *http.Request) (any, error) {
+		c, err := s.channel(r)
+		if err != nil {
+			return nil, err
+		}
+		var create object
+		if _, err = s.readBody(r, &create); err != nil {
+			return nil, err
+		}
+		if create.string("name") == "" {
+			return nil, errFieldRequired("name")
+		}
+
+		id := s.newID()
+		webhook := object{
+			"id":             id.String(),
+			"type":           discord.WebhookTypeIncoming,
+			"channel_id":     c.object["id"],
+			"guild_id":       c.object["guild_id"],
+			"name":           create.string("name"),
+			"avatar":         nil,
+			"token":          rand.Text(),
+			"application_id": nil,
+			"user":           s.users[s.config.BotUser.ID],
+		}
+		s.webhooks[id] = webhook
+		return webhook, nil
+	})
+
+	s.handle("GET /channels/{channel_id}/webhooks", true, func(r *http.Request) (any, error) {
+		c, err := s.channel(r)
+		if err != nil {
+			return nil, err
+		}
+		return s.filterWebhooks("channel_id", c.id("id")), nil
+	})
+
+	s.handle("GET /guilds/{guild_id}/webhooks", true, func(r *http.Request) (any, error) {
+		g, err := s.guild(r)
+		if err != nil {
+			return nil, err
+		}
+		return s.filterWebhooks("guild_id", g.id("id")), nil
+	})
+
+	s.handle("GET /webhooks/{webhook_id}", true, func(r *http.Request) (any, error) {
+		return s.webhook(r)
+	})
+
+	s.handle("PATCH /webhooks/{webhook_id}", true, func(r *http.Request) (any, error) {
+		webhook, err := s.webhook(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		if _, err = s.readBody(r, &update); err != nil {
+			return nil, err
+		}
+		if channelID := update.id("channel_id"); channelID != 0 {
+			c, ok := s.channels[channelID]
+			if !ok {
+				return nil, errUnknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+			}
+			update["guild_id"] = c.object["guild_id"]
+		}
+		webhook.merge(update, "id", "type", "token", "user", "application_id")
+		return webhook, nil
+	})
+
+	s.handle("DELETE /webhooks/{webhook_id}", true, func(r *http.Request) (any, error) {
+		webhook, err := s.webhook(r)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.webhooks, webhook.id("id"))
+		return nil, nil
+	})
+
+	s.handle("GET /webhooks/{webhook_id}/{webhook_token}", false, func(r *http.Request) (any, error) {
+		webhook, err := s.webhookWithToken(r)
+		if err != nil {
+			return nil, err
+		}
+		return withoutUser(webhook), nil
+	})
+
+	s.handle("PATCH /webhooks/{webhook_id}/{webhook_token}", false, func(r *http.Request) (any, error) {
+		webhook, err := s.webhookWithToken(r)
+		if err != nil {
+			return nil, err
+		}
+		var update object
+		if _, err = s.readBody(r, &update); err != nil {
+			return nil, err
+		}
+		// the channel can't be changed with the webhook token
+		webhook.merge(update, "id", "type", "token", "user", "application_id", "channel_id", "guild_id")
+		return withoutUser(webhook), nil
+	})
+
+	s.handle("DELETE /webhooks/{webhook_id}/{webhook_token}", false, func(r *http.Request) (any, error) {
+		webhook, err := s.webhookWithToken(r)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.webhooks, webhook.id("id"))
+		return nil, nil
+	})
+
+	// interaction followup messages share these routes with the application ID as webhook ID and the interaction token as webhook token
+	s.handle("POST /webhooks/{webhook_id}/{webhook_token}", false, func(r *http.Request) (any, error) {
+		if in := s.tokenInteraction(r); in != nil {
+			return s.createFollowup(r, in)
+		}
+		webhook, err := s.webhookWithToken(r)
+		if err != nil {
+			return nil, err
+		}
+		var create object
+		files, err := s.readBody(r, &create)
+		if err != nil {
+			return nil, err
+		}
+		if err = validateMessage(create, files); err != nil {
+			return nil, err
+		}
+
+		author := object{
+			"id":            webhook["id"],
+			"username":      webhook["name"],
+			"discriminator": "0000",
+			"avatar":        webhook["avatar"],
+			"bot":           true,
+		}
+		if username := create.string("username"); username != "" {
+			author["username"] = username
+		}
+		msg := s.newMessage(s.webhookChannel(r, webhook), author, create, files)
+		msg["webhook_id"] = webhook["id"]
+
+		if r.URL.Query().Get("wait") != "true" {
+			return nil, nil
+		}
+		return msg, nil
+	})
+
+	s.handle("GET /webhooks/{webhook_id}/{webhook_token}/messages/{message_id}", false, func(r *http.Request) (any, error) {
+		if in := s.tokenInteraction(r); in != nil {
+			return in.message(pathID(r, "message_id"))
+		}
+		webhook, err := s.webhookWithToken(r)
+		if err != nil {
+			return nil, err
+		}
+		return s.webhookMessage(r, webhook)
+	})
+
+	s.handle("PATCH /webhooks/{webhook_id}/{webhook_token}/messages/{message_id}", false, func(r *http.Request) (any, error) {
+		var msg object
+		if in := s.tokenInteraction(r); in != nil {
+			var err error
+			if msg, err = in.message(pathID(r, "message_id")); err != nil {
+				return nil, err
+			}
+		} else {
+			webhook, err := s.webhookWithToken(r)
+			if err != nil {
+				return nil, err
+			}
+			if msg, err = s.webhookMessage(r, webhook); err != nil {
+				return nil, err
+			}
+		}
+
+		var update object
+		files, err := s.readBody(r, &update)
+		if err != nil {
+			return nil, err
+		}
+		updateMessage(msg, update, files)
+		return msg, nil
+	})
+
+	s.handle("DELETE /webhooks/{webhook_id}/{webhook_token}/messages/{message_id}", false, func(r *http.Request) (any, error) {
+		if in := s.tokenInteraction(r); in != nil {
+			msg, err := in.message(pathID(r, "message_id"))
+			if err != nil {
+				return nil, err
+			}
+			s.deleteInteractionMessage(in, msg)
+			return nil, nil
+		}
+		webhook, err := s.webhookWithToken(r)
+		if err != nil {
+			return nil, err
+		}
+		msg, err := s.webhookMessage(r, webhook)
+		if err != nil {
+			return nil, err
+		}
+		s.deleteMessage(msg)
+		return nil, nil
+	})
+}
+
+func (s *Server) filterWebhooks(key string, id snowflake.ID) []object {
+	webhooks := []object{}
+	for _, webhook := range s.webhooks {
+		if webhook.id(key) == id {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	slices.SortFunc(webhooks, func(a, b object) int {
+		return compareIDs(a.id("id"), b.id("id"))
+	})
+	return webhooks
+}
@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"context"
+	"iter"
 	"time"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -26,10 +28,19 @@ type Threads interface {
 	GetThreadMember(threadID snowflake.ID, userID snowflake.ID, withMember bool, opts ...RequestOpt) (threadMember *discord.ThreadMember, err error)
 	GetThreadMembers(threadID snowflake.ID, opts ...RequestOpt) (threadMembers []discord.ThreadMember, err error)
 	GetThreadMembersPage(threadID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) ThreadMemberPage
+	// ThreadMembersIter returns an iterator over the members of a thread with a user ID after startID. It stops after limit members, or never if limit is 0.
+	ThreadMembersIter(ctx context.Context, threadID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.ThreadMember, error]
 
 	GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
+	// PublicArchivedThreadsIter returns an iterator over the public archived threads of a channel archived before start, newest first. It stops after limit threads, or never if limit is 0.
+	PublicArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, start time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error]
+	// PrivateArchivedThreadsIter returns an iterator over the private archived threads of a channel archived before start, newest first. It stops after limit threads, or never if limit is 0.
+	PrivateArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, start time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error]
 	GetJoinedPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
+	// JoinedPrivateArchivedThreadsIter returns an iterator over the private archived threads of a channel the current user joined with an ID before startID, newest first.
+	// Unlike the other archived threads, Discord paginates them by ID. It stops after limit threads, or never if limit is 0.
+	JoinedPrivateArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error]
 	GetActiveGuildThreads(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildActiveThreads, error)
 }
 
@@ -98,6 +109,19 @@ func (s *threadImpl) GetThreadMembersPage(threadID snowflake.ID, startID snowfla
 	}
 }
 
+func (s *threadImpl) ThreadMembersIter(ctx context.Context, threadID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.ThreadMember, error] {
+	opts = withIterCtx(ctx, opts)
+	return iterPages(ctx, PageDirectionForward, startID, limit, func(_ snowflake.ID, after snowflake.ID) ([]discord.ThreadMember, error) {
+		return s.getThreadMembers(threadID, discord.QueryValues{
+			"with_member": true,
+			"after":       after,
+			"limit":       100,
+		}, opts...)
+	}, func(threadMember discord.ThreadMember) snowflake.ID {
+		return threadMember.UserID
+	})
+}
+
 func (s *threadImpl) GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error) {
 	queryValues := discord.QueryValues{}
 	if !before.IsZero() {
@@ -122,6 +146,30 @@ func (s *threadImpl) GetPrivateArchivedThreads(channelID snowflake.ID, before ti
 	return
 }
 
+func (s *threadImpl) PublicArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, start time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error] {
+	return s.archivedThreadsIter(ctx, start, limit, func(before time.Time) (*discord.GetThreads, error) {
+		return s.GetPublicArchivedThreads(channelID, before, 100, withIterCtx(ctx, opts)...)
+	})
+}
+
+func (s *threadImpl) PrivateArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, start time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error] {
+	return s.archivedThreadsIter(ctx, start, limit, func(before time.Time) (*discord.GetThreads, error) {
+		return s.GetPrivateArchivedThreads(channelID, before, 100, withIterCtx(ctx, opts)...)
+	})
+}
+
+func (s *threadImpl) archivedThreadsIter(ctx context.Context, start time.Time, limit int, getThreads func(before time.Time) (*discord.GetThreads, error)) iter.Seq2[discord.GuildThread, error] {
+	return iterTimePages(ctx, start, limit, func(before time.Time) ([]discord.GuildThread, bool, error) {
+		threads, err := getThreads(before)
+		if err != nil {
+			return nil, false, err
+		}
+		return threads.Threads, threads.HasMore, nil
+	}, func(thread discord.GuildThread) time.Time {
+		return thread.ThreadMetadata.ArchiveTimestamp
+	})
+}
+
 func (s *threadImpl) GetJoinedPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error) {
 	queryValues := discord.QueryValues{}
 	if !before.IsZero() {
@@ -134,6 +182,23 @@ func (s *threadImpl) GetJoinedPrivateArchivedThreads(channelID snowflake.ID, bef
 	return
 }
 
+func (s *threadImpl) JoinedPrivateArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error] {
+	opts = withIterCtx(ctx, opts)
+	return iterPagesHasMore(ctx, PageDirectionBackward, startID, limit, func(before snowflake.ID, _ snowflake.ID) ([]discord.GuildThread, bool, error) {
+		queryValues := discord.QueryValues{"limit": 100}
+		if before != 0 {
+			queryValues["before"] = before
+		}
+		var threads *discord.GetThreads
+		if err := s.client.Do(GetJoinedPrivateArchivedThreads.Compile(queryValues, channelID), nil, &threads, opts...); err != nil {
+			return nil, false, err
+		}
+		return threads.Threads, threads.HasMore, nil
+	}, func(thread discord.GuildThread) snowflake.ID {
+		return thread.ID()
+	})
+}
+
 func (s *threadImpl) GetActiveGuildThreads(guildID snowflake.ID, opts ...RequestOpt) (activeThreads *discord.GuildActiveThreads, err error) {
 	err = s.client.Do(GetActiveGuildThreads.Compile(nil, guildID), nil, &activeThreads, opts...)
 	return
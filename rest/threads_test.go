@@ -0,0 +1,54 @@
+package rest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestJoinedPrivateArchivedThreadsIter(t *testing.T) {
+	// threads 1-5 are returned newest first, 2 per page
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		before := 6
+		if v := r.URL.Query().Get("before"); v != "" {
+			before, _ = strconv.Atoi(v)
+		}
+		var threads string
+		for id := before - 1; id > 0 && id > before-3; id-- {
+			if threads != "" {
+				threads += ","
+			}
+			threads += fmt.Sprintf(`{"id":"%d","type":12,"thread_metadata":{}}`, id)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"threads":[%s],"members":[],"has_more":%t}`, threads, before > 3)
+	}))
+	defer server.Close()
+	client := rest.New(rest.NewClient("token", rest.WithURL(server.URL), rest.WithRateLimiter(rest.NewNoopRateLimiter())))
+
+	var got []snowflake.ID
+	for thread, err := range client.JoinedPrivateArchivedThreadsIter(context.Background(), 1, 0, 0) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, thread.ID())
+	}
+	if want := []snowflake.ID{5, 4, 3, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	// the last page reports has_more=false, so no empty page is requested
+	if n := requests.Load(); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+}
@@ -25,9 +25,13 @@ type ShardManager interface {
 	// Open opens all configured shards.
 	Open(ctx context.Context)
 
-	// Close closes all shards.
+	// Close closes all shards. See gateway.Gateway.Close.
 	Close(ctx context.Context)
 
+	// CloseWithCode closes all shards with the given code & message.
+	// Use websocket.CloseNormalClosure to invalidate the sessions of all shards even if a gateway.SessionStore is set.
+	CloseWithCode(ctx context.Context, code int, message string)
+
 	// OpenShard opens a specific shard.
 	OpenShard(ctx context.Context, shardID int) error
 
@@ -150,16 +154,29 @@ func (m *shardManagerImpl) Open(ctx context.Context) {
 }
 
 func (m *shardManagerImpl) Close(ctx context.Context) {
-	m.config.Logger.Debug("closing shards", slog.String("shard_ids", fmt.Sprint(slices.Collect(maps.Keys(m.shards)))))
+	m.closeShards(func(shard gateway.Gateway) {
+		shard.Close(ctx)
+	})
+}
+
+func (m *shardManagerImpl) CloseWithCode(ctx context.Context, code int, message string) {
+	m.closeShards(func(shard gateway.Gateway) {
+		shard.CloseWithCode(ctx, code, message)
+	})
+}
+
+// closeShards closes all shards concurrently with the given close func and removes them.
+func (m *shardManagerImpl) closeShards(closeFunc func(shard gateway.Gateway)) {
 	var wg sync.WaitGroup
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
+	m.config.Logger.Debug("closing shards", slog.String("shard_ids", fmt.Sprint(slices.Collect(maps.Keys(m.shards)))))
 	for _, shard := range m.shards {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			shard.Close(ctx)
+			closeFunc(shard)
 		}()
 	}
 	wg.Wait()
@@ -209,13 +226,16 @@ func (m *shardManagerImpl) openShard(ctx context.Context, shardID int, shardCoun
 
 	// this looks funny, but basically we want to first pass in the close handler so it can be overwritten by the user config options,
 	// and then we should apply the user config options.
-	// After that we pass in the shardID, shardCount, sessionID, sequence and resumeURL so they can't be overwritten by the user config options.
+	// After that we pass in the shardID, shardCount, session store, sessionID, sequence and resumeURL so they can't be overwritten by the user config options.
 	opts := append([]gateway.ConfigOpt{gateway.WithCloseHandler(m.closeHandler), gateway.WithIdentifyRateLimiter(m.config.IdentifyRateLimiter)},
 		append(slices.Clone(m.config.GatewayConfigOpts),
 			gateway.WithShardID(shardID),
 			gateway.WithShardCount(shardCount),
 		)...,
 	)
+	if m.config.SessionStore != nil {
+		opts = append(opts, gateway.WithSessionStore(m.config.SessionStore))
+	}
 	if state.SessionID != "" {
 		opts = append(opts, gateway.WithSessionID(state.SessionID))
 	}
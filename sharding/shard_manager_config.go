@@ -40,6 +40,8 @@ type config struct {
 	GatewayCreateFunc gateway.CreateFunc
 	// GatewayConfigOpts are the ConfigOpt(s) which are applied to the gateway.Gateway.
 	GatewayConfigOpts []gateway.ConfigOpt
+	// SessionStore is the gateway.SessionStore which is used by all shards to persist their sessions. Defaults to nil (no persistence).
+	SessionStore gateway.SessionStore
 	// IdentifyRateLimiter is the RateLimiter which is used by the ShardManager. Defaults to NewRateLimiter()
 	IdentifyRateLimiter gateway.IdentifyRateLimiter
 	// IdentifyRateLimiterConfigOpts are the gateway.IdentifyRateLimiterConfigOpt(s) which are applied to the gateway.IdentifyRateLimiter.
@@ -125,6 +127,15 @@ func WithGatewayConfigOpts(opts ...gateway.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithSessionStore sets the gateway.SessionStore all shards use to persist their sessions, so they resume after a restart instead of identifying again.
+// States set via WithShardIDsWithStates or ResumeShard take precedence over stored sessions.
+// Shards closed with ShardManager.Close keep their sessions, use ShardManager.CloseWithCode with websocket.CloseNormalClosure to delete them.
+func WithSessionStore(sessionStore gateway.SessionStore) ConfigOpt {
+	return func(config *config) {
+		config.SessionStore = sessionStore
+	}
+}
+
 // WithIdentifyRateLimiter lets you inject your own RateLimiter into the ShardManager.
 func WithIdentifyRateLimiter(rateLimiter gateway.IdentifyRateLimiter) ConfigOpt {
 	return func(config *config) {
@@ -1,10 +1,20 @@
 package sharding
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/gateway/gatewaytest"
 )
 
 type shard struct {
@@ -51,3 +61,90 @@ func printShards(shards []shard, guildIDs []snowflake.ID) {
 		}
 	}
 }
+
+// recordingSessionStore records the shard IDs of all loaded sessions.
+type recordingSessionStore struct {
+	gateway.SessionStore
+	mu       sync.Mutex
+	shardIDs []int
+}
+
+func (s *recordingSessionStore) Get(ctx context.Context, shardID int) (*gateway.Session, error) {
+	s.mu.Lock()
+	s.shardIDs = append(s.shardIDs, shardID)
+	s.mu.Unlock()
+	return s.SessionStore.Get(ctx, shardID)
+}
+
+func TestShardManager_SessionStore(t *testing.T) {
+	store := &recordingSessionStore{SessionStore: gateway.NewMemorySessionStore()}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	m := New("token", func(gateway.Gateway, gateway.EventType, int, gateway.EventData) {},
+		WithLogger(logger),
+		WithShardIDs(0, 1, 2),
+		WithShardCount(3),
+		WithSessionStore(store),
+		WithIdentifyRateLimiter(gateway.NewNoopIdentifyRateLimiter()),
+		WithGatewayConfigOpts(
+			gateway.WithLogger(logger),
+			// nothing listens on port 0, so the shards fail to connect after loading their session
+			gateway.WithURL("ws://127.0.0.1:0"),
+			gateway.WithBackoffPolicy(gateway.BackoffPolicy{Base: time.Millisecond, MaxAttempts: 1}),
+		),
+	)
+	m.Open(context.Background())
+	defer m.Close(context.Background())
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	slices.Sort(store.shardIDs)
+	if !slices.Equal(store.shardIDs, []int{0, 1, 2}) {
+		t.Errorf("expected sessions of shards [0 1 2] to be loaded, got %v", store.shardIDs)
+	}
+}
+
+func TestShardManager_RestartResumes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	fake := gatewaytest.NewServer(gatewaytest.WithLogger(logger))
+	defer fake.Close()
+	store := gateway.NewMemorySessionStore()
+
+	newShardManager := func() ShardManager {
+		return New("token", func(gateway.Gateway, gateway.EventType, int, gateway.EventData) {},
+			WithLogger(logger),
+			WithShardIDs(0),
+			WithShardCount(1),
+			WithSessionStore(store),
+			WithIdentifyRateLimiter(gateway.NewNoopIdentifyRateLimiter()),
+			WithGatewayConfigOpts(
+				gateway.WithLogger(logger),
+				gateway.WithURL(fake.GatewayURL()),
+			),
+		)
+	}
+
+	m := newShardManager()
+	m.Open(ctx)
+	if _, err := fake.NextConn(ctx); err != nil {
+		t.Fatal(err)
+	}
+	m.Close(ctx)
+	if session, _ := store.Get(ctx, 0); session == nil {
+		t.Fatal("expected the session to be kept on close")
+	}
+
+	identifies := fake.Identifies()
+	m = newShardManager()
+	m.Open(ctx)
+	defer m.CloseWithCode(context.Background(), websocket.CloseNormalClosure, "Shutting down")
+	conn, err := fake.NextConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !conn.Resumed() || fake.Resumes() != 1 || fake.Identifies() != identifies {
+		t.Errorf("expected the restarted shard to resume, got %d identifies & %d resumes", fake.Identifies()-identifies, fake.Resumes())
+	}
+}
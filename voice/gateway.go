@@ -19,13 +19,12 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/disgoorg/disgo/discord"
+	botgateway "github.com/disgoorg/disgo/gateway"
 )
 
 // GatewayVersion is the version of the voice gateway we are using.
 const GatewayVersion = 8
 
-const maximumConnectDelay = 10 * time.Second
-
 // ErrGatewayNotConnected is returned when the gateway is not connected and a message is attempted to be sent.
 var ErrGatewayNotConnected = fmt.Errorf("voice gateway not connected")
 
@@ -59,6 +58,10 @@ type (
 	// CloseObserver is a function that observes the close of the voice gateway and can be used for monitoring.
 	// It is called with the error that caused the close and is called in its own goroutine.
 	CloseObserver func(err error)
+
+	// ReconnectObserver is a function that observes the reconnect attempts of the voice gateway and can be used for monitoring.
+	// It is called before each attempt.
+	ReconnectObserver func(attempt botgateway.EventReconnectAttempt)
 )
 
 // State is the current state of the voice conn.
@@ -139,7 +142,7 @@ func (g *gatewayImpl) SSRC() uint32 {
 }
 
 func (g *gatewayImpl) Open(ctx context.Context, state State) error {
-	return g.doReconnect(ctx, state)
+	return g.doReconnect(ctx, state, false)
 }
 
 func (g *gatewayImpl) open(ctx context.Context, state State) error {
@@ -306,24 +309,28 @@ func (g *gatewayImpl) Latency() time.Duration {
 	return g.lastHeartbeatReceived.Sub(g.lastHeartbeatSent)
 }
 
-func (g *gatewayImpl) doReconnect(ctx context.Context, state State) error {
-	var (
-		try              int
-		backoffIncrement int
-	)
+// doReconnect opens the Gateway, retrying as configured by the gateway.BackoffPolicy.
+// If reconnecting is true, the ReconnectObserver is called before every attempt, otherwise only before retries.
+func (g *gatewayImpl) doReconnect(ctx context.Context, state State, reconnecting bool) error {
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		if g.config.BackoffPolicy.Exhausted(attempt) {
+			return fmt.Errorf("%w: %w", botgateway.ErrMaxReconnectAttempts, lastErr)
+		}
 
-	for {
-		// Exponentially backoff up to a limit of 10s
-		delay := time.Duration(1<<backoffIncrement) * time.Second
-		if delay > maximumConnectDelay {
-			delay = maximumConnectDelay
-		} else {
-			backoffIncrement++
+		delay := g.config.BackoffPolicy.Delay(attempt)
+		if g.config.ReconnectObserver != nil && (reconnecting || attempt > 0) {
+			g.config.ReconnectObserver(botgateway.EventReconnectAttempt{
+				Attempt: attempt + 1,
+				Delay:   delay,
+				Err:     lastErr,
+			})
 		}
 
 		timer := time.NewTimer(delay)
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return ctx.Err()
 		case <-timer.C:
 		}
@@ -341,22 +348,22 @@ func (g *gatewayImpl) doReconnect(ctx context.Context, state State) error {
 		var closeError *websocket.CloseError
 		if errors.As(err, &closeError) {
 			closeCode := GatewayCloseEventCodeByCode(closeError.Code)
-			if !closeCode.Reconnect {
+			if !g.config.BackoffPolicy.Reconnect(closeError.Code, closeCode.Reconnect) {
 				return err
 			}
 		}
 
-		g.config.Logger.Error("failed to reconnect voice gateway", slog.Any("err", err), slog.Int("try", try), slog.Duration("delay", delay))
+		g.config.Logger.Error("failed to reconnect voice gateway", slog.Any("err", err), slog.Int("try", attempt), slog.Duration("delay", delay))
 		g.statusMu.Lock()
 		g.status = StatusDisconnected
 		g.statusMu.Unlock()
 
-		try++
+		lastErr = err
 	}
 }
 
 func (g *gatewayImpl) reconnect() {
-	if err := g.doReconnect(context.Background(), g.state); err != nil {
+	if err := g.doReconnect(context.Background(), g.state, true); err != nil {
 		g.config.Logger.Error("failed to reopen voice gateway", slog.Any("err", err))
 
 		g.closeHandlerFunc(g, err)
@@ -490,7 +497,7 @@ func (g *gatewayImpl) listen(conn *websocket.Conn, ready func(error)) {
 			var closeError *websocket.CloseError
 			if errors.As(err, &closeError) {
 				closeCode := GatewayCloseEventCodeByCode(closeError.Code)
-				reconnect = closeCode.Reconnect
+				reconnect = g.config.BackoffPolicy.Reconnect(closeError.Code, closeCode.Reconnect)
 
 				msg := "voice gateway close received"
 				args := []any{
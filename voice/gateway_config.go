@@ -2,21 +2,29 @@ package voice
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/gorilla/websocket"
+
+	botgateway "github.com/disgoorg/disgo/gateway"
 )
 
 func defaultGatewayConfig() gatewayConfig {
+	backoffPolicy := botgateway.DefaultBackoffPolicy()
+	backoffPolicy.Max = 10 * time.Second
 	return gatewayConfig{
-		Logger: slog.Default(),
-		Dialer: websocket.DefaultDialer,
+		Logger:        slog.Default(),
+		Dialer:        websocket.DefaultDialer,
+		BackoffPolicy: backoffPolicy,
 	}
 }
 
 type gatewayConfig struct {
-	Logger   *slog.Logger
-	Dialer   *websocket.Dialer
-	Observer CloseObserver
+	Logger            *slog.Logger
+	Dialer            *websocket.Dialer
+	Observer          CloseObserver
+	ReconnectObserver ReconnectObserver
+	BackoffPolicy     botgateway.BackoffPolicy
 }
 
 // GatewayConfigOpt is used to functionally configure a gatewayConfig.
@@ -49,3 +57,19 @@ func WithGatewayCloseObserver(observer CloseObserver) GatewayConfigOpt {
 		config.Observer = observer
 	}
 }
+
+// WithGatewayReconnectObserver sets the Gateway(s) used ReconnectObserver.
+func WithGatewayReconnectObserver(observer ReconnectObserver) GatewayConfigOpt {
+	return func(config *gatewayConfig) {
+		config.ReconnectObserver = observer
+	}
+}
+
+// WithGatewayBackoffPolicy sets the gateway.BackoffPolicy which decides how long the Gateway(s) wait between (re)connect attempts,
+// when they give up and after which close codes they reconnect. The delay is capped at 10s by default.
+// A zero Base or Max is taken from the default policy.
+func WithGatewayBackoffPolicy(policy botgateway.BackoffPolicy) GatewayConfigOpt {
+	return func(config *gatewayConfig) {
+		config.BackoffPolicy = policy.WithDefaults(defaultGatewayConfig().BackoffPolicy)
+	}
+}